@@ -2,3 +2,13 @@
 data "hwmux_device" "example" {
   id = "1"
 }
+
+# Fetch a device by name
+data "hwmux_device" "by_name" {
+  sn_or_name = "my_board"
+}
+
+# List the user groups allowed to change the device
+output "device_editors" {
+  value = [for group, permissions in data.hwmux_device.example.permissions.user_groups : group if contains(permissions, "change")]
+}
@@ -0,0 +1,8 @@
+# Fetch all the nightly device groups a permission group has access to
+data "hwmux_device_groups" "example" {
+  name_regex       = "^nightly_"
+  permission_group = "Example group name"
+  metadata = {
+    "pool" = "ci"
+  }
+}
@@ -0,0 +1,9 @@
+# Fetch all online BRD4180 boards in Room_0
+data "hwmux_devices" "example" {
+  part   = "BRD4180A"
+  room   = "Room_0"
+  online = true
+  metadata = {
+    "board_rev" = "A01"
+  }
+}
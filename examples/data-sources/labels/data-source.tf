@@ -0,0 +1,5 @@
+# Fetch all the labels created by Terraform, along with their permissions
+data "hwmux_labels" "example" {
+  source              = "TERRAFORM"
+  include_permissions = true
+}
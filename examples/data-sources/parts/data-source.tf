@@ -0,0 +1,5 @@
+# Fetch all the BRD41xx parts of a part family
+data "hwmux_parts" "example" {
+  part_family   = "PartFamily_0"
+  part_no_regex = "^BRD41"
+}
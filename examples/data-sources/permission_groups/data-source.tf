@@ -0,0 +1,4 @@
+# Fetch all the permission groups that can create reservations
+data "hwmux_permission_groups" "example" {
+  permission = "add_reservation"
+}
@@ -0,0 +1,5 @@
+# Fetch all the lab rooms of a site
+data "hwmux_rooms" "example" {
+  site       = "Site_0"
+  name_regex = "^Lab"
+}
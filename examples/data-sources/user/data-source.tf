@@ -0,0 +1,8 @@
+# Reference an account created by SSO
+data "hwmux_user" "example" {
+  username = "jane.doe"
+}
+
+output "jane_is_staff" {
+  value = data.hwmux_user.example.is_staff
+}
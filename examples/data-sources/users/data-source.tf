@@ -0,0 +1,6 @@
+# Fetch all the CI service accounts of a permission group
+data "hwmux_users" "example" {
+  permission_group = "Example group name"
+  is_staff         = false
+  username_prefix  = "ci_"
+}
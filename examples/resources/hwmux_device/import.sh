@@ -1,2 +1,5 @@
 # A device can be imported by specifying its ID
 terraform import hwmux_device.example 123
+
+# or its sn_or_name
+terraform import hwmux_device.example sn_or_name:my_device
@@ -5,6 +5,15 @@ resource "hwmux_device" "example" {
   part              = "Part_no_0"
   room              = "Room_0"
   is_wstk           = false
-  online            = true
+  permission_groups = ["Example group name"]
+}
+
+# Take a device out of rotation while it is being repaired
+resource "hwmux_device" "in_repair" {
+  sn_or_name        = "broken device"
+  part              = "Part_no_0"
+  room              = "Room_0"
+  status            = "OFFLINE"
+  status_comment    = "Radio board sent for repair"
   permission_groups = ["Example group name"]
 }
@@ -1,2 +1,7 @@
 # A deviceGroup can be imported by specifying its ID
 terraform import hwmux_device_group.example 123
+
+# or its name
+terraform import hwmux_device_group.example name:my_testbed
+
+# Imported device groups do not track their devices until devices is set in the configuration
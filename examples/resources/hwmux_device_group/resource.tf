@@ -7,3 +7,35 @@ resource "hwmux_device_group" "new_testbed" {
   enable_ahs_actions = true
   enable_ahs_cas     = true
 }
+
+# metadata can also be set key by key, so that plans show the changed keys.
+# Each value is json encoded, so that it keeps its type in hwmux
+resource "hwmux_device_group" "map_metadata" {
+  name = "map_metadata"
+  metadata_object = {
+    owner    = jsonencode("team_a")
+    revision = jsonencode("123")
+    channels = jsonencode(4)
+    config   = jsonencode({ "retries" = 3 })
+  }
+  devices           = [3]
+  permission_groups = ["Example group name"]
+}
+
+# Terraform only owns the listed metadata keys, the keys written by other tools are kept
+resource "hwmux_device_group" "shared_metadata" {
+  name                  = "shared_metadata"
+  metadata_object       = { owner = jsonencode("team_a") }
+  managed_metadata_keys = ["owner", "pool"]
+  devices               = [4]
+  permission_groups     = ["Example group name"]
+}
+
+# Disable a testbed, the reason is recorded in hwmux
+resource "hwmux_device_group" "disabled" {
+  name              = "disabled_testbed"
+  devices           = [5]
+  permission_groups = ["Example group name"]
+  status            = "DISABLED"
+  status_comment    = "Reserved for the firmware release"
+}
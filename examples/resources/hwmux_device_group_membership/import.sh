@@ -0,0 +1,2 @@
+# A device group membership can be imported by specifying the device group ID and the device ID
+terraform import hwmux_device_group_membership.example 12/34
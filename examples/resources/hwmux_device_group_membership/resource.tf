@@ -0,0 +1,10 @@
+# The devices of the group are managed with memberships, so devices must not be set
+resource "hwmux_device_group" "shared_pool" {
+  name              = "shared_pool"
+  permission_groups = ["Example group name"]
+}
+
+resource "hwmux_device_group_membership" "team_a_device" {
+  device_group_id = hwmux_device_group.shared_pool.id
+  device_id       = 1
+}
@@ -1,2 +1,7 @@
 # A label can be imported by specifying its ID
 terraform import hwmux_label.example 123
+
+# or its name
+terraform import hwmux_label.example name:my_label
+
+# Imported labels do not track their device groups until device_groups is set in the configuration
@@ -0,0 +1,2 @@
+# A label attachment can be imported by specifying the label ID and the device group ID
+terraform import hwmux_label_attachment.example 12/34
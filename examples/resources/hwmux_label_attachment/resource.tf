@@ -0,0 +1,10 @@
+# The device groups of the label are managed with attachments, so device_groups must not be set
+resource "hwmux_label" "shared_label" {
+  name              = "shared_label"
+  permission_groups = ["Example group name"]
+}
+
+resource "hwmux_label_attachment" "team_a_group" {
+  label_id        = hwmux_label.shared_label.id
+  device_group_id = 1
+}
@@ -0,0 +1,5 @@
+# An object permission granted to a user group can be imported by specifying the object type and ID, the group name and the permission
+terraform import hwmux_object_permission.team_device "device/1/user_group/Example group name/change"
+
+# An object permission granted to a user can be imported by specifying the object type and ID, the username and the permission
+terraform import hwmux_object_permission.user_label label/2/user/jane.doe/view
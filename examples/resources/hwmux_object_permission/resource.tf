@@ -0,0 +1,15 @@
+# Allow a team to change a device
+resource "hwmux_object_permission" "team_device" {
+  object_type = "device"
+  object_id   = 1
+  user_group  = "Example group name"
+  permission  = "change"
+}
+
+# Allow a single user to view a label
+resource "hwmux_object_permission" "user_label" {
+  object_type = "label"
+  object_id   = 2
+  user        = "jane.doe"
+  permission  = "view"
+}
@@ -0,0 +1,2 @@
+# A part can be imported by specifying its part number
+terraform import hwmux_part.example BRD4180B
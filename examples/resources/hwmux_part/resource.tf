@@ -0,0 +1,9 @@
+resource "hwmux_part" "new_part" {
+  part_no     = "BRD4180B"
+  part_family = hwmux_part_family.new_part_family.name
+  board_no    = "BRD4180"
+  chip_no     = "EFR32MG21"
+  variant     = "B"
+  revision    = "B01"
+  metadata    = jsonencode(yamldecode(file("example.yaml")))
+}
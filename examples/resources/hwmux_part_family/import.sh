@@ -0,0 +1,2 @@
+# A part family can be imported by specifying its name
+terraform import hwmux_part_family.example radio_boards
@@ -0,0 +1,6 @@
+resource "hwmux_part_family" "new_part_family" {
+  name          = "radio_boards"
+  regex_pattern = "^BRD41[0-9]{2}[A-Z]$"
+  description   = "Radio boards"
+  metadata      = jsonencode({ "vendor" = "silabs" })
+}
@@ -1,3 +1,8 @@
 resource "hwmux_permission_group" "new_permission_group" {
   name = "New team"
 }
+
+resource "hwmux_permission_group" "operators" {
+  name        = "Operators"
+  permissions = ["view_device", "change_device", "add_reservation"]
+}
@@ -0,0 +1,2 @@
+# The members of a permission group can be imported by specifying the group name
+terraform import hwmux_permission_group_members.example "Example group name"
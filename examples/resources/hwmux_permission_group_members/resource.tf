@@ -0,0 +1,5 @@
+# Users added to the group outside of this resource are removed from it
+resource "hwmux_permission_group_members" "team" {
+  group     = "Example group name"
+  usernames = ["jane.doe", "john.doe"]
+}
@@ -0,0 +1,2 @@
+# A permission group membership can be imported by specifying the group name and the username
+terraform import hwmux_permission_group_membership.example "Example group name/jane.doe"
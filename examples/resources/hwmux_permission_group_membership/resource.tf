@@ -0,0 +1,5 @@
+# Add a user that is not managed by Terraform, such as an SSO account, to a group
+resource "hwmux_permission_group_membership" "sso_user" {
+  group    = "Example group name"
+  username = "jane.doe"
+}
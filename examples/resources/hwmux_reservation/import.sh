@@ -0,0 +1,2 @@
+# A reservation can be imported by specifying its UUID
+terraform import hwmux_reservation.example 3fa85f64-5717-4562-b3fc-2c963f66afa6
@@ -0,0 +1,11 @@
+# Reserve a device group for the lifetime of a test environment
+resource "hwmux_reservation" "example" {
+  device_groups    = [hwmux_device_group.example.id]
+  details          = "Nightly regression"
+  lease_duration_s = 7200
+
+  # wait up to an hour for the hardware to become available
+  timeouts {
+    create = "1h"
+  }
+}
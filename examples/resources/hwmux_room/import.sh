@@ -0,0 +1,2 @@
+# A room can be imported by specifying its name
+terraform import hwmux_room.example lab_room
@@ -0,0 +1,6 @@
+resource "hwmux_room" "new_room" {
+  name        = "lab_room"
+  site        = "Example site name"
+  description = "Bring-up lab"
+  metadata    = jsonencode({ "floor" = 2 })
+}
@@ -1,3 +1,12 @@
 resource "hwmux_token" "example" {
   user_id = "id_or_username"
 }
+
+# Rotate the token every 30 days, and whenever the CI configuration changes
+resource "hwmux_token" "ci" {
+  user_id      = "ci_user"
+  rotate_after = "720h"
+  rotation_triggers = {
+    ci_config = "v2"
+  }
+}
@@ -1,2 +1,2 @@
-# A user can be imported by specifying its ID
-terraform import hwmux_user.example 123
+# A user can be imported by specifying its username, or its ID
+terraform import hwmux_user.example jane.doe
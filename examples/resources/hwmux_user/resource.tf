@@ -2,4 +2,24 @@ resource "hwmux_user" "new_user" {
   username          = "new user"
   password          = "a password"
   permission_groups = ["A permission group"]
-}
\ No newline at end of file
+}
+
+# Service account with a write-only password, which is not stored in the state.
+# Requires Terraform 1.11 or later, change password_wo_version to rotate it.
+ephemeral "random_password" "ci" {
+  length = 32
+}
+
+resource "hwmux_user" "ci" {
+  username            = "ci_service_account"
+  password_wo         = ephemeral.random_password.ci.result
+  password_wo_version = "2024-01"
+  permission_groups   = ["A permission group"]
+}
+
+# Existing account imported with `terraform import hwmux_user.sso jane.doe`,
+# managed without touching its password
+resource "hwmux_user" "sso" {
+  username          = "jane.doe"
+  permission_groups = ["A permission group"]
+}
@@ -2,107 +2,330 @@ package hwmux
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/Silabs-UTF/hwmux-client-golang/v2"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Get device, err and set error
-func GetDevice(client *hwmux.APIClient, diagnostics *diag.Diagnostics, id int32) (
+func GetDevice(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, id int32) (
 	device *hwmux.DeviceSerializerPublic, httpRes *http.Response, err error) {
-	device, httpRes, err = client.DevicesApi.DevicesRetrieve(context.Background(), id).IncludePermissionGroups(true).Execute()
+	device, httpRes, err = client.DevicesApi.DevicesRetrieve(ctx, id).IncludePermissionGroups(true).Execute()
 	handleError(httpRes, err, diagnostics, "Device")
 	return
 }
 
 // Get deviceGroup, err and set error
-func GetDeviceGroup(client *hwmux.APIClient, diagnostics *diag.Diagnostics, id int32) (
+func GetDeviceGroup(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, id int32) (
 	deviceGroup *hwmux.DeviceGroup, httpRes *http.Response, err error) {
-	deviceGroup, httpRes, err = client.GroupsApi.GroupsRetrieve(context.Background(), id).IncludePermissionGroups(true).Execute()
+	deviceGroup, httpRes, err = client.GroupsApi.GroupsRetrieve(ctx, id).IncludePermissionGroups(true).Execute()
 	handleError(httpRes, err, diagnostics, "Device Group")
 	return
 }
 
 // Get label, err and set error
-func GetLabel(client *hwmux.APIClient, diagnostics *diag.Diagnostics, id int32) (
+func GetLabel(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, id int32) (
 	label *hwmux.Label, httpRes *http.Response, err error) {
-	label, httpRes, err = client.LabelsApi.LabelsRetrieve(context.Background(), id).IncludePermissionGroups(true).Execute()
+	label, httpRes, err = client.LabelsApi.LabelsRetrieve(ctx, id).IncludePermissionGroups(true).Execute()
 	handleError(httpRes, err, diagnostics, "Label")
 	return
 }
 
 // Get part, err and set error
-func GetPart(client *hwmux.APIClient, diagnostics *diag.Diagnostics, part_no string) (
+func GetPart(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, part_no string) (
 	part *hwmux.Part, httpRes *http.Response, err error) {
-	part, httpRes, err = client.PartsApi.PartsRetrieve(context.Background(), part_no).Execute()
+	part, httpRes, err = client.PartsApi.PartsRetrieve(ctx, part_no).Execute()
 	handleError(httpRes, err, diagnostics, "Part")
 	return
 }
 
+// Get part family, err and set error
+func GetPartFamily(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, name string) (
+	partFamily *hwmux.PartFamily, httpRes *http.Response, err error) {
+	partFamily, httpRes, err = client.PartFamiliesApi.PartFamiliesRetrieve(ctx, name).Execute()
+	handleError(httpRes, err, diagnostics, "Part Family")
+	return
+}
+
 // Get room, err and set error
-func GetRoom(client *hwmux.APIClient, diagnostics *diag.Diagnostics, name string) (
+func GetRoom(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, name string) (
 	room *hwmux.Room, httpRes *http.Response, err error) {
-	room, httpRes, err = client.RoomsApi.RoomsRetrieve(context.Background(), name).Execute()
+	room, httpRes, err = client.RoomsApi.RoomsRetrieve(ctx, name).Execute()
 	handleError(httpRes, err, diagnostics, "Room")
 	return
 }
 
 // Get permission group, err and set error
-func GetPermissionGroup(client *hwmux.APIClient, diagnostics *diag.Diagnostics, name string) (
+func GetPermissionGroup(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, name string) (
 	permissionGroup *hwmux.PermissionGroup, httpRes *http.Response, err error) {
-	permissionGroup, httpRes, err = client.PermissionsApi.PermissionsGroupsRetrieve(context.Background(), name).Execute()
+	permissionGroup, httpRes, err = client.PermissionsApi.PermissionsGroupsRetrieve(ctx, name).Execute()
 	handleError(httpRes, err, diagnostics, "Permission Group")
 	return
 }
 
 // Get token, err and set error
-func GetToken(client *hwmux.APIClient, diagnostics *diag.Diagnostics, username string) (
+func GetToken(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, username string) (
 	token *hwmux.Token, httpRes *http.Response, err error) {
-	token, httpRes, err = client.UserApi.UserTokenRetrieve(context.Background(), username).Execute()
+	token, httpRes, err = client.UserApi.UserTokenRetrieve(ctx, username).Execute()
 	handleError(httpRes, err, diagnostics, "Token")
 	return
 }
 
 // Get user, err and set error
-func GetUser(client *hwmux.APIClient, diagnostics *diag.Diagnostics, username string) (
+func GetUser(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, username string) (
 	user *hwmux.LoggedInUser, httpRes *http.Response, err error) {
-	user, httpRes, err = client.UserApi.UserRetrieve(context.Background(), username).Execute()
+	user, httpRes, err = client.UserApi.UserRetrieve(ctx, username).Execute()
 	handleError(httpRes, err, diagnostics, "User")
 	return
 }
 
 // Get Location by device id
-func GetDeviceLocation(client *hwmux.APIClient, diagnostics *diag.Diagnostics, id int32) (
+func GetDeviceLocation(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, id int32) (
 	location *hwmux.Location, httpRes *http.Response, err error) {
-	location, httpRes, err = client.DevicesApi.DevicesLocationRetrieve(context.Background(), strconv.Itoa(int(id))).Execute()
+	location, httpRes, err = client.DevicesApi.DevicesLocationRetrieve(ctx, strconv.Itoa(int(id))).Execute()
 	handleError(httpRes, err, diagnostics, "Device Location")
 	return
 }
 
+// Get a reservation
+func GetReservation(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, id string) (
+	reservation *hwmux.ReservationSessionSerializerReadOnly, httpRes *http.Response, err error) {
+	reservation, httpRes, err = client.ReservationsApi.ReservationsRetrieve(ctx, id).Execute()
+	handleError(httpRes, err, diagnostics, "Reservation")
+	return
+}
+
 // Get permission groups for a given deviceGroup
-func GetPermissionGroupsForDeviceGroup(client *hwmux.APIClient, diagnostics *diag.Diagnostics, id int32) (
+func GetPermissionGroupsForDeviceGroup(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, id int32) (
 	[]string, error) {
-	objectPerms, httpRes, err := client.GroupsApi.GroupsPermissionsRetrieve(context.Background(), id).Execute()
+	objectPerms, httpRes, err := client.GroupsApi.GroupsPermissionsRetrieve(ctx, id).Execute()
 	handleError(httpRes, err, diagnostics, "Permissions for Device Group")
-	return objectPermsToUGList(objectPerms), nil
+	return objectPermsToUGList(objectPerms), err
 }
 
 // Get permission groups for a given device
-func GetPermissionGroupsForDevice(client *hwmux.APIClient, diagnostics *diag.Diagnostics, id int32) (
+func GetPermissionGroupsForDevice(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, id int32) (
 	[]string, error) {
-	objectPerms, httpRes, err := client.DevicesApi.DevicesPermissionsRetrieve(context.Background(), id).Execute()
+	objectPerms, httpRes, err := client.DevicesApi.DevicesPermissionsRetrieve(ctx, id).Execute()
 	handleError(httpRes, err, diagnostics, "Permissions for Device")
-	return objectPermsToUGList(objectPerms), nil
+	return objectPermsToUGList(objectPerms), err
 }
 
 // Get permission groups for a given Label
-func GetPermissionGroupsForLabel(client *hwmux.APIClient, diagnostics *diag.Diagnostics, id int32) (
+func GetPermissionGroupsForLabel(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, id int32) (
 	[]string, error) {
-	objectPerms, httpRes, err := client.LabelsApi.LabelsPermissionsRetrieve(context.Background(), id).Execute()
+	objectPerms, httpRes, err := client.LabelsApi.LabelsPermissionsRetrieve(ctx, id).Execute()
 	handleError(httpRes, err, diagnostics, "Permissions for Label")
-	return objectPermsToUGList(objectPerms), nil
+	return objectPermsToUGList(objectPerms), err
+}
+
+// Get the object-level permissions of a device, device group or label
+func GetObjectPermissions(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, objectType string, id int32) (
+	objectPerms *hwmux.ObjectPermissions, httpRes *http.Response, err error) {
+	switch objectType {
+	case "device":
+		objectPerms, httpRes, err = client.DevicesApi.DevicesPermissionsRetrieve(ctx, id).Execute()
+	case "device_group":
+		objectPerms, httpRes, err = client.GroupsApi.GroupsPermissionsRetrieve(ctx, id).Execute()
+	case "label":
+		objectPerms, httpRes, err = client.LabelsApi.LabelsPermissionsRetrieve(ctx, id).Execute()
+	default:
+		err = fmt.Errorf("unsupported object type %s", objectType)
+	}
+	handleError(httpRes, err, diagnostics, "Permissions for "+objectType)
+	return
+}
+
+// Get the permissions granted to a user or user group from the object-level permissions returned by hwmux
+func objectPermissionsOf(grants map[string]interface{}, principal string) []string {
+	values, _ := grants[principal].([]interface{})
+	permissions := make([]string, 0, len(values))
+	for _, value := range values {
+		if permission, ok := value.(string); ok {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions
+}
+
+// A page of results returned by a hwmux list endpoint
+type paginatedList[T any] interface {
+	GetResults() []T
+	GetNext() string
+}
+
+// Fetch every page of a hwmux list endpoint and return all results
+func listAllPages[T any, P paginatedList[T]](diagnostics *diag.Diagnostics, name string,
+	fetchPage func(page int32) (P, *http.Response, error)) (results []T, err error) {
+	for page := int32(1); ; page++ {
+		resultPage, httpRes, err := fetchPage(page)
+		handleError(httpRes, err, diagnostics, name)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, resultPage.GetResults()...)
+		if resultPage.GetNext() == "" {
+			return results, nil
+		}
+	}
+}
+
+// List all devices matching the given request, following pagination
+func ListDevices(diagnostics *diag.Diagnostics, request hwmux.ApiDevicesListRequest) ([]hwmux.DeviceSerializerPublic, error) {
+	return listAllPages[hwmux.DeviceSerializerPublic](diagnostics, "Devices",
+		func(page int32) (*hwmux.PaginatedDeviceSerializerPublicList, *http.Response, error) {
+			return request.Page(page).Execute()
+		})
+}
+
+// List all device groups matching the given request, following pagination
+func ListDeviceGroups(diagnostics *diag.Diagnostics, request hwmux.ApiGroupsListRequest) ([]hwmux.DeviceGroup, error) {
+	return listAllPages[hwmux.DeviceGroup](diagnostics, "Device Groups",
+		func(page int32) (*hwmux.PaginatedDeviceGroupList, *http.Response, error) {
+			return request.Page(page).Execute()
+		})
+}
+
+// List all labels matching the given request, following pagination
+func ListLabels(diagnostics *diag.Diagnostics, request hwmux.ApiLabelsListRequest) ([]hwmux.Label, error) {
+	return listAllPages[hwmux.Label](diagnostics, "Labels",
+		func(page int32) (*hwmux.PaginatedLabelList, *http.Response, error) {
+			return request.Page(page).Execute()
+		})
+}
+
+// List all rooms matching the given request, following pagination
+func ListRooms(diagnostics *diag.Diagnostics, request hwmux.ApiRoomsListRequest) ([]hwmux.Room, error) {
+	return listAllPages[hwmux.Room](diagnostics, "Rooms",
+		func(page int32) (*hwmux.PaginatedRoomList, *http.Response, error) {
+			return request.Page(page).Execute()
+		})
+}
+
+// List all parts matching the given request, following pagination
+func ListParts(diagnostics *diag.Diagnostics, request hwmux.ApiPartsListRequest) ([]hwmux.Part, error) {
+	return listAllPages[hwmux.Part](diagnostics, "Parts",
+		func(page int32) (*hwmux.PaginatedPartList, *http.Response, error) {
+			return request.Page(page).Execute()
+		})
+}
+
+// List all permission groups matching the given request, following pagination
+func ListPermissionGroups(diagnostics *diag.Diagnostics, request hwmux.ApiPermissionsGroupsListRequest) (
+	[]hwmux.PermissionGroup, error) {
+	return listAllPages[hwmux.PermissionGroup](diagnostics, "Permission Groups",
+		func(page int32) (*hwmux.PaginatedPermissionGroupList, *http.Response, error) {
+			return request.Page(page).Execute()
+		})
+}
+
+// List all users matching the given request, following pagination
+func ListUsers(diagnostics *diag.Diagnostics, request hwmux.ApiUserListRequest) ([]hwmux.LoggedInUser, error) {
+	return listAllPages[hwmux.LoggedInUser](diagnostics, "Users",
+		func(page int32) (*hwmux.PaginatedLoggedInUserList, *http.Response, error) {
+			return request.Page(page).Execute()
+		})
+}
+
+// Get the device with the given sn_or_name. Sets an error if no device or more than one device match.
+func GetDeviceBySnOrName(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, snOrName string) (
+	*hwmux.DeviceSerializerPublic, error) {
+	devices, err := ListDevices(diagnostics, client.DevicesApi.DevicesList(ctx).SnOrName(snOrName))
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []hwmux.DeviceSerializerPublic
+	for _, device := range devices {
+		if device.GetSnOrName() == snOrName {
+			matches = append(matches, device)
+		}
+	}
+
+	err = checkSingleMatch(len(matches), "device", snOrName, diagnostics)
+	if err != nil {
+		return nil, err
+	}
+	return &matches[0], nil
+}
+
+// Get the device group with the given name. Sets an error if no device group matches.
+func GetDeviceGroupByName(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, name string) (
+	*hwmux.DeviceGroup, error) {
+	deviceGroups, err := ListDeviceGroups(diagnostics, client.GroupsApi.GroupsList(ctx).Name(name))
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []hwmux.DeviceGroup
+	for _, deviceGroup := range deviceGroups {
+		if deviceGroup.GetName() == name {
+			matches = append(matches, deviceGroup)
+		}
+	}
+
+	err = checkSingleMatch(len(matches), "device group", name, diagnostics)
+	if err != nil {
+		return nil, err
+	}
+	return &matches[0], nil
+}
+
+// Get the label with the given name. Sets an error if no label matches.
+func GetLabelByName(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, name string) (
+	*hwmux.Label, error) {
+	labels, err := ListLabels(diagnostics, client.LabelsApi.LabelsList(ctx).Name(name))
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []hwmux.Label
+	for _, label := range labels {
+		if label.GetName() == name {
+			matches = append(matches, label)
+		}
+	}
+
+	err = checkSingleMatch(len(matches), "label", name, diagnostics)
+	if err != nil {
+		return nil, err
+	}
+	return &matches[0], nil
+}
+
+// Sets an error unless a lookup by name found exactly one object
+func checkSingleMatch(count int, objectType string, name string, diagnostics *diag.Diagnostics) error {
+	if count == 1 {
+		return nil
+	}
+	err := fmt.Errorf("expected exactly one %s named %q, found %d", objectType, name, count)
+	diagnostics.AddError("Unable to find "+objectType+" "+name, err.Error())
+	return err
+}
+
+// Import a resource by its numeric ID, or by name when the import ID is formatted as "<prefix>:<name>".
+// The name is resolved to the ID with getIDByName before the first Read.
+func importStateByIDOrName(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse,
+	prefix string, getIDByName func(name string) (int32, error)) {
+	if !strings.HasPrefix(req.ID, prefix+":") {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	id, err := getIDByName(strings.TrimPrefix(req.ID, prefix+":"))
+	if err != nil {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.Itoa(int(id)))...)
 }
 
 // Returns all user group names from the given object permissions object
@@ -121,7 +344,7 @@ func handleError(httpRes *http.Response, err error, diagnostics *diag.Diagnostic
 	if err != nil {
 		errorStr := err.Error()
 		if httpRes != nil {
-			errorStr += "\nHwmux response body:" + BodyToString(&httpRes.Body)
+			errorStr += "\nHwmux response body:" + ResponseBodyToString(httpRes)
 		}
 		diagnostics.AddError(
 			"Unable to Read "+name,
@@ -130,8 +353,32 @@ func handleError(httpRes *http.Response, err error, diagnostics *diag.Diagnostic
 	}
 }
 
+// Returns true if hwmux reported that the requested object does not exist
+func IsNotFound(httpRes *http.Response) bool {
+	return httpRes != nil && httpRes.StatusCode == http.StatusNotFound
+}
+
+// Removes the resource from state when the read request returned a 404, so that Terraform plans to re-create it.
+// Otherwise, the read diagnostics are forwarded to the response. Returns true if the resource was removed.
+func removeResourceIfNotFound(ctx context.Context, httpRes *http.Response, readDiagnostics diag.Diagnostics,
+	resp *resource.ReadResponse, name string) bool {
+	if IsNotFound(httpRes) {
+		tflog.Warn(ctx, name+" no longer exists in hwmux, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return true
+	}
+	resp.Diagnostics.Append(readDiagnostics...)
+	return false
+}
+
+// Update of the resources whose attributes all require a replacement, except the timeouts which are not sent to hwmux,
+// so the plan only needs to be saved in the state.
+func updateTimeoutsOnly(req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.State.Raw = req.Plan.Raw
+}
+
 // modify user permissions. Sets diagnostics and returns error
-func processUserPermissions(user *hwmux.LoggedInUser, plan *UserResourceModel, diagnostics *diag.Diagnostics, client *hwmux.APIClient) error {
+func processUserPermissions(ctx context.Context, user *hwmux.LoggedInUser, plan *UserResourceModel, diagnostics *diag.Diagnostics, client *hwmux.APIClient) error {
 	desired := make(map[string]bool)
 	existing := make(map[string]bool)
 
@@ -148,16 +395,7 @@ func processUserPermissions(user *hwmux.LoggedInUser, plan *UserResourceModel, d
 	// removed permissions when they exist but are not desired
 	for groupName := range existing {
 		if !desired[groupName] {
-			httpRes, err := client.PermissionsApi.PermissionsGroupsUsersDestroy(context.Background(), groupName, user.GetUsername()).Execute()
-			if err != nil {
-				errorStr := err.Error()
-				if httpRes != nil {
-					errorStr += "\nHwmux response body:" + BodyToString(&httpRes.Body)
-				}
-				diagnostics.AddError(
-					"Unable to remove user "+user.GetUsername()+" from group "+groupName,
-					errorStr,
-				)
+			if _, err := RemoveUserFromPermissionGroup(ctx, client, diagnostics, groupName, user.GetUsername()); err != nil {
 				return err
 			}
 		}
@@ -165,16 +403,7 @@ func processUserPermissions(user *hwmux.LoggedInUser, plan *UserResourceModel, d
 	// add permissions when they are desired but do not exist
 	for groupName := range desired {
 		if !existing[groupName] {
-			_, httpRes, err := client.PermissionsApi.PermissionsGroupsUsersCreate(context.Background(), groupName).User([]hwmux.User{*hwmux.NewUser(user.GetUsername())}).Execute()
-			if err != nil {
-				errorStr := err.Error()
-				if httpRes != nil {
-					errorStr += "\nHwmux response body:" + BodyToString(&httpRes.Body)
-				}
-				diagnostics.AddError(
-					"Unable to add user "+user.GetUsername()+" to group "+groupName,
-					errorStr,
-				)
+			if _, err := AddUserToPermissionGroup(ctx, client, diagnostics, groupName, user.GetUsername()); err != nil {
 				return err
 			}
 		}
@@ -187,3 +416,82 @@ func processUserPermissions(user *hwmux.LoggedInUser, plan *UserResourceModel, d
 
 	return nil
 }
+
+// Add a user to a permission group
+func AddUserToPermissionGroup(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, groupName string,
+	username string) (*http.Response, error) {
+	_, httpRes, err := client.PermissionsApi.PermissionsGroupsUsersCreate(ctx, groupName).User([]hwmux.User{*hwmux.NewUser(username)}).Execute()
+	if err != nil {
+		errorStr := err.Error()
+		if httpRes != nil {
+			errorStr += "\nHwmux response body:" + ResponseBodyToString(httpRes)
+		}
+		diagnostics.AddError(
+			"Unable to add user "+username+" to group "+groupName,
+			errorStr,
+		)
+	}
+	return httpRes, err
+}
+
+// Remove a user from a permission group
+func RemoveUserFromPermissionGroup(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, groupName string,
+	username string) (*http.Response, error) {
+	httpRes, err := client.PermissionsApi.PermissionsGroupsUsersDestroy(ctx, groupName, username).Execute()
+	if err != nil {
+		errorStr := err.Error()
+		if httpRes != nil {
+			errorStr += "\nHwmux response body:" + ResponseBodyToString(httpRes)
+		}
+		diagnostics.AddError(
+			"Unable to remove user "+username+" from group "+groupName,
+			errorStr,
+		)
+	}
+	return httpRes, err
+}
+
+// Import a resource identified by several numeric IDs, formatted as "<id1>/<id2>"
+func importStateByCompositeID(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse,
+	attributes ...string) {
+	ids := strings.Split(req.ID, "/")
+	values := make([]int64, len(ids))
+	var err error
+	for i, id := range ids {
+		if values[i], err = strconv.ParseInt(id, 10, 64); err != nil {
+			break
+		}
+	}
+	if len(ids) != len(attributes) || err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: %s. Got: %q", strings.Join(attributes, "/"), req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	for i, attribute := range attributes {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(attribute), values[i])...)
+	}
+}
+
+// The non-authoritative resources update hwmux objects with a read-modify-write, serialize the updates of each object
+var objectLocks = struct {
+	sync.Mutex
+	locks map[string]*sync.Mutex
+}{locks: map[string]*sync.Mutex{}}
+
+// Lock the hwmux object with the given key until the returned function is called
+func lockObject(key string) func() {
+	objectLocks.Lock()
+	lock, ok := objectLocks.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		objectLocks.locks[key] = lock
+	}
+	objectLocks.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
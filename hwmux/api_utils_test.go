@@ -0,0 +1,245 @@
+package hwmux
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Start a fake hwmux server with the given handler and return a client pointing to it
+func newFakeHwmuxClient(t *testing.T, handler http.HandlerFunc) *hwmux.APIClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	clientConfig := hwmux.NewConfiguration()
+	clientConfig.Servers = hwmux.ServerConfigurations{hwmux.ServerConfiguration{URL: server.URL}}
+	return hwmux.NewAPIClient(clientConfig)
+}
+
+// Build a resource state with the given attributes set
+func newFakeResourceState(t *testing.T, r resource.Resource, attributes map[string]string) tfsdk.State {
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	for name, value := range attributes {
+		diags := state.SetAttribute(ctx, path.Root(name), value)
+		if diags.HasError() {
+			t.Fatalf("unable to set attribute %s: %v", name, diags)
+		}
+	}
+	return state
+}
+
+// Run the Read method of the resource against a fake hwmux server answering every request with the given status
+func readAgainstFakeHwmux(t *testing.T, r resource.Resource, attributes map[string]string, status int) *resource.ReadResponse {
+	ctx := context.Background()
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(`{"detail": "Not found."}`))
+	})
+
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: client}, &resource.ConfigureResponse{})
+
+	state := newFakeResourceState(t, r, attributes)
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+	return resp
+}
+
+var readTestCases = map[string]struct {
+	resource   resource.Resource
+	attributes map[string]string
+}{
+	"device":           {NewDeviceResource(), map[string]string{"id": "1"}},
+	"device_group":     {NewDeviceGroupResource(), map[string]string{"id": "1"}},
+	"label":            {NewLabelResource(), map[string]string{"id": "1"}},
+	"user":             {NewUserResource(), map[string]string{"id": "1", "username": "user"}},
+	"permission_group": {NewPermissionGroupResource(), map[string]string{"id": "1"}},
+	"token":            {NewTokenResource(), map[string]string{"id": "1", "user_id": "1"}},
+	"room":             {NewRoomResource(), map[string]string{"id": "Room_0", "site": "Site_0"}},
+	"part_family":      {NewPartFamilyResource(), map[string]string{"id": "PartFamily_0"}},
+	"part":             {NewPartResource(), map[string]string{"id": "Part_no_0", "part_family": "PartFamily_0"}},
+	"permission_group_membership": {NewPermissionGroupMembershipResource(),
+		map[string]string{"id": "group/user", "group": "group", "username": "user"}},
+	"permission_group_members": {NewPermissionGroupMembersResource(), map[string]string{"id": "group", "group": "group"}},
+	"object_permission": {NewObjectPermissionResource(),
+		map[string]string{"id": "device/1/user_group/group/view", "object_type": "device", "user_group": "group", "permission": "view"}},
+	"reservation": {NewReservationResource(), map[string]string{"id": "abc"}},
+}
+
+func TestReadRemovesResourceOnNotFound(t *testing.T) {
+	for name, tc := range readTestCases {
+		t.Run(name, func(t *testing.T) {
+			resp := readAgainstFakeHwmux(t, tc.resource, tc.attributes, http.StatusNotFound)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error diagnostics: %v", resp.Diagnostics)
+			}
+			if !resp.State.Raw.IsNull() {
+				t.Fatalf("expected the resource to be removed from state")
+			}
+		})
+	}
+}
+
+func TestReadFailsOnServerError(t *testing.T) {
+	for name, tc := range readTestCases {
+		t.Run(name, func(t *testing.T) {
+			resp := readAgainstFakeHwmux(t, tc.resource, tc.attributes, http.StatusInternalServerError)
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatalf("expected error diagnostics")
+			}
+			if resp.State.Raw.IsNull() {
+				t.Fatalf("expected the resource to be kept in state")
+			}
+		})
+	}
+}
+
+func TestGetHelpersUseRequestContext(t *testing.T) {
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		t.Errorf("no request should reach hwmux once the context is cancelled, got %s %s", req.Method, req.URL)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var diagnostics diag.Diagnostics
+	_, httpRes, err := GetDevice(ctx, client, &diagnostics, 1)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if httpRes != nil {
+		t.Fatalf("expected no response, got status %d", httpRes.StatusCode)
+	}
+	if !diagnostics.HasError() {
+		t.Fatalf("expected error diagnostics")
+	}
+}
+
+func TestListDevicesFollowsPagination(t *testing.T) {
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("part") != "BRD4180" {
+			t.Errorf("expected the part filter to be sent to hwmux, got %q", req.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Query().Get("page") {
+		case "1":
+			w.Write([]byte(`{"count": 3, "next": "http://hwmux/devices/?page=2", "results": [{"id": 1}, {"id": 2}]}`))
+		case "2":
+			w.Write([]byte(`{"count": 3, "next": null, "results": [{"id": 3}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	var diagnostics diag.Diagnostics
+	devices, err := ListDevices(&diagnostics, client.DevicesApi.DevicesList(context.Background()).Part("BRD4180"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s %v", err, diagnostics)
+	}
+	if len(devices) != 3 {
+		t.Fatalf("expected 3 devices, got %d", len(devices))
+	}
+	for i, device := range devices {
+		if device.GetId() != int32(i+1) {
+			t.Errorf("expected device %d to have id %d, got %d", i, i+1, device.GetId())
+		}
+	}
+}
+
+func TestGetDeviceBySnOrName(t *testing.T) {
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Query().Get("sn_or_name") {
+		case "unique":
+			w.Write([]byte(`{"count": 1, "next": null, "results": [{"id": 7, "sn_or_name": "unique"}]}`))
+		case "duplicated":
+			w.Write([]byte(`{"count": 2, "next": null, "results": [{"id": 1}, {"id": 2}]}`))
+		default:
+			w.Write([]byte(`{"count": 0, "next": null, "results": []}`))
+		}
+	})
+
+	var diagnostics diag.Diagnostics
+	device, err := GetDeviceBySnOrName(context.Background(), client, &diagnostics, "unique")
+	if err != nil || device.GetId() != 7 {
+		t.Fatalf("expected device 7, got %v (%v)", device, err)
+	}
+
+	for _, name := range []string{"duplicated", "missing"} {
+		diagnostics = diag.Diagnostics{}
+		_, err = GetDeviceBySnOrName(context.Background(), client, &diagnostics, name)
+		if err == nil || !diagnostics.HasError() {
+			t.Errorf("expected an error when looking up %q", name)
+		}
+	}
+}
+
+func TestImportStateByName(t *testing.T) {
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Query().Get("name") + req.URL.Query().Get("sn_or_name") {
+		case "my_testbed":
+			w.Write([]byte(`{"count": 2, "next": null, "results": [` +
+				`{"id": 41, "name": "my_testbed_2", "sn_or_name": "my_testbed_2"}, ` +
+				`{"id": 42, "name": "my_testbed", "sn_or_name": "my_testbed"}]}`))
+		default:
+			w.Write([]byte(`{"count": 0, "next": null, "results": []}`))
+		}
+	})
+
+	importTestCases := map[string]struct {
+		resource resource.Resource
+		prefix   string
+	}{
+		"device":       {NewDeviceResource(), "sn_or_name:"},
+		"device_group": {NewDeviceGroupResource(), "name:"},
+		"label":        {NewLabelResource(), "name:"},
+	}
+
+	for name, tc := range importTestCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			tc.resource.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: client}, &resource.ConfigureResponse{})
+			importer := tc.resource.(resource.ResourceWithImportState)
+
+			for importID, expectedID := range map[string]string{tc.prefix + "my_testbed": "42", "7": "7"} {
+				resp := &resource.ImportStateResponse{State: newFakeResourceState(t, tc.resource, nil)}
+				importer.ImportState(ctx, resource.ImportStateRequest{ID: importID}, resp)
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected error importing %q: %v", importID, resp.Diagnostics)
+				}
+
+				var id string
+				resp.State.GetAttribute(ctx, path.Root("id"), &id)
+				if id != expectedID {
+					t.Errorf("expected %q to be imported with id %s, got %q", importID, expectedID, id)
+				}
+			}
+
+			resp := &resource.ImportStateResponse{State: newFakeResourceState(t, tc.resource, nil)}
+			importer.ImportState(ctx, resource.ImportStateRequest{ID: tc.prefix + "missing"}, resp)
+			if !resp.Diagnostics.HasError() {
+				t.Errorf("expected an error when importing an unknown name")
+			}
+		})
+	}
+}
@@ -5,13 +5,17 @@ import (
 	"fmt"
 
 	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ datasource.DataSource = &DeviceDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &DeviceDataSource{}
 
 func NewDeviceDataSource() datasource.DataSource {
 	return &DeviceDataSource{}
@@ -33,6 +37,7 @@ type DeviceDataSourceModel struct {
 	Wstk_part     types.String `tfsdk:"wstk_part"`
 	Source        types.String `tfsdk:"source"`
 	Socketed_chip types.String `tfsdk:"socketed_chip"`
+	Permissions   types.Object `tfsdk:"permissions"`
 }
 
 func (d *DeviceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -46,11 +51,13 @@ func (d *DeviceDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
-				MarkdownDescription: "Device identifier",
-				Required:            true,
+				MarkdownDescription: "Device identifier. Exactly one of `id` or `sn_or_name` must be set.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"sn_or_name": schema.StringAttribute{
-				MarkdownDescription: "Device name. Must be unique.",
+				MarkdownDescription: "Device name. Must be unique. Exactly one of `id` or `sn_or_name` must be set.",
+				Optional:            true,
 				Computed:            true,
 			},
 			"uri": schema.StringAttribute{
@@ -85,10 +92,20 @@ func (d *DeviceDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				Description: "The socket chip detail of the device.",
 				Computed:    true,
 			},
+			"permissions": objectPermissionsDataSourceAttribute("device"),
 		},
 	}
 }
 
+func (d *DeviceDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("sn_or_name"),
+		),
+	}
+}
+
 func (d *DeviceDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -119,11 +136,37 @@ func (d *DeviceDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	device, _, err := GetDevice(d.client, &resp.Diagnostics, int32(data.ID.ValueInt64()))
+	var device *hwmux.DeviceSerializerPublic
+	var err error
+	if !data.Sn_or_name.IsNull() {
+		device, err = GetDeviceBySnOrName(ctx, d.client, &resp.Diagnostics, data.Sn_or_name.ValueString())
+	} else {
+		device, _, err = GetDevice(ctx, d.client, &resp.Diagnostics, int32(data.ID.ValueInt64()))
+	}
+	if err != nil {
+		return
+	}
+
+	err = updateDeviceDataSourceModelFromResponse(device, &data, &resp.Diagnostics)
+	if err != nil {
+		return
+	}
+
+	objectPerms, _, err := GetObjectPermissions(ctx, d.client, &resp.Diagnostics, "device", device.GetId())
+	if err != nil {
+		return
+	}
+	data.Permissions, err = objectPermissionsValue(ctx, objectPerms, &resp.Diagnostics)
 	if err != nil {
 		return
 	}
 
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Map response body to the data source model
+func updateDeviceDataSourceModelFromResponse(device *hwmux.DeviceSerializerPublic, data *DeviceDataSourceModel, diagnostics *diag.Diagnostics) error {
 	data.ID = types.Int64Value(int64(device.GetId()))
 	data.Sn_or_name = types.StringValue(device.GetSnOrName())
 	data.Is_wstk = types.BoolValue(device.GetIsWstk())
@@ -132,15 +175,17 @@ func (d *DeviceDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	data.Source = types.StringValue(string(device.GetSource()))
 	data.Socketed_chip = types.StringValue(device.GetSocketedChip())
 
-	err = MarshalMetadataSetError(device.GetMetadata(), &resp.Diagnostics, "device", &data.Metadata)
+	err := MarshalMetadataSetError(device.GetMetadata(), diagnostics, "device", &data.Metadata)
 	if err != nil {
-		return
+		return err
 	}
 
 	data.Part = types.StringValue(device.Part.GetPartNo())
 
 	data.Wstk_part = types.StringValue(device.GetWstkPart())
 
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	// the permissions are read separately, only when they are requested
+	data.Permissions = types.ObjectNull(objectPermissionsAttrTypes)
+
+	return nil
 }
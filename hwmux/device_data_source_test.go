@@ -1,6 +1,7 @@
 package hwmux
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -24,8 +25,34 @@ func TestAccDeviceDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.hwmux_device.test", "uri", "0"),
 					resource.TestCheckResourceAttr("data.hwmux_device.test", "source", "TERRAFORM"),
 					resource.TestCheckResourceAttr("data.hwmux_device.test", "socketed_chip", ""),
+					resource.TestCheckResourceAttrSet("data.hwmux_device.test", "permissions.user_groups.%"),
+					resource.TestCheckResourceAttrSet("data.hwmux_device.test", "permissions.users.%"),
 				),
 			},
+			// Read by name testing
+			{
+				Config: providerConfig + `data "hwmux_device" "test" {sn_or_name = "sn0"}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hwmux_device.test", "sn_or_name", "sn0"),
+					resource.TestCheckResourceAttr("data.hwmux_device.test", "id", "1"),
+					resource.TestCheckResourceAttr("data.hwmux_device.test", "part", "Part_no_0"),
+				),
+			},
+			// Unknown name
+			{
+				Config:      providerConfig + `data "hwmux_device" "test" {sn_or_name = "does_not_exist"}`,
+				ExpectError: regexp.MustCompile("expected exactly one device"),
+			},
+			// Both lookup keys
+			{
+				Config: providerConfig + `
+data "hwmux_device" "test" {
+	id         = 1
+	sn_or_name = "sn0"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
 		},
 	})
 }
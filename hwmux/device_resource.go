@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -15,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -33,20 +36,25 @@ type DeviceResource struct {
 
 // DeviceResourceModel describes the resource data model.
 type DeviceResourceModel struct {
-	ID               types.String   `tfsdk:"id"`
-	Sn_or_name       types.String   `tfsdk:"sn_or_name"`
-	Is_wstk          types.Bool     `tfsdk:"is_wstk"`
-	Uri              types.String   `tfsdk:"uri"`
-	Online           types.Bool     `tfsdk:"online"`
-	Metadata         types.String   `tfsdk:"metadata"`
-	Part             types.String   `tfsdk:"part"`
-	Wstk_part        types.String   `tfsdk:"wstk_part"`
-	Room             types.String   `tfsdk:"room"`
-	LocationMetadata types.String   `tfsdk:"location_metadata"`
-	PermissionGroups []types.String `tfsdk:"permission_groups"`
-	LastUpdated      types.String   `tfsdk:"last_updated"`
-	Source           types.String   `tfsdk:"source"`
-	Socketed_chip    types.String   `tfsdk:"socketed_chip"`
+	ID                  types.String   `tfsdk:"id"`
+	Sn_or_name          types.String   `tfsdk:"sn_or_name"`
+	Is_wstk             types.Bool     `tfsdk:"is_wstk"`
+	Uri                 types.String   `tfsdk:"uri"`
+	Online              types.Bool     `tfsdk:"online"`
+	Status              types.String   `tfsdk:"status"`
+	StatusComment       types.String   `tfsdk:"status_comment"`
+	Metadata            types.String   `tfsdk:"metadata"`
+	MetadataObject      types.Map      `tfsdk:"metadata_object"`
+	ManagedMetadataKeys types.Set      `tfsdk:"managed_metadata_keys"`
+	Part                types.String   `tfsdk:"part"`
+	Wstk_part           types.String   `tfsdk:"wstk_part"`
+	Room                types.String   `tfsdk:"room"`
+	LocationMetadata    types.String   `tfsdk:"location_metadata"`
+	PermissionGroups    []types.String `tfsdk:"permission_groups"`
+	LastUpdated         types.String   `tfsdk:"last_updated"`
+	Source              types.String   `tfsdk:"source"`
+	Socketed_chip       types.String   `tfsdk:"socketed_chip"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *DeviceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -101,19 +109,48 @@ func (r *DeviceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:            true,
 			},
 			"online": schema.BoolAttribute{
-				MarkdownDescription: "If the device is online.",
-				Computed:            true,
-				Optional:            true,
+				MarkdownDescription: "If the device is online, which is the case when its `status` is `ACTIVE`. " +
+					"Setting it to `false` disables the device.",
+				DeprecationMessage: "Use status instead.",
+				Computed:           true,
+				Optional:           true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The status of the device, one of %s. The status set in hwmux, "+
+					"for instance from the UI, is kept when neither `status` nor `online` is configured. "+
+					"Removing `status` from the configuration leaves the device in its current status.", strings.Join(statusEnumValues(), ", ")),
+				Computed: true,
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					deviceStatusUseStateForUnknown{},
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(statusEnumValues()...),
+					stringvalidator.ConflictsWith(path.MatchRoot("online")),
+				},
+			},
+			"status_comment": schema.StringAttribute{
+				MarkdownDescription: "The reason of the status, recorded in hwmux when the status is set. " +
+					"hwmux does not return it, so changes made outside of Terraform are not detected.",
+				Optional: true,
 			},
 			"metadata": schema.StringAttribute{
 				MarkdownDescription: "The metadata of the device.",
 				Computed:            true,
 				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					metadataSemanticEquality{},
+				},
 			},
+			"metadata_object":       metadataObjectAttribute("device"),
+			"managed_metadata_keys": managedMetadataKeysAttribute("device"),
 			"location_metadata": schema.StringAttribute{
 				MarkdownDescription: "The location metadata of the device.",
 				Computed:            true,
 				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					metadataSemanticEquality{},
+				},
 			},
 			"permission_groups": schema.SetAttribute{
 				MarkdownDescription: "Which permission groups can access the resource.",
@@ -137,6 +174,9 @@ func (r *DeviceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -170,6 +210,9 @@ func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "create", defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	writeOnlyDevice, err := createDeviceFromPlan(data, &resp.Diagnostics)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -179,31 +222,36 @@ func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// create new device
-	writeOnlyDevice, httpRes, err := r.client.DevicesApi.DevicesCreate(context.Background()).WriteOnlyDevice(*writeOnlyDevice).Execute()
+	writeOnlyDevice, httpRes, err := r.client.DevicesApi.DevicesCreate(ctx).WriteOnlyDevice(*writeOnlyDevice).Execute()
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Error creating device %s", data.Sn_or_name.String()),
-			fmt.Sprintf("Could not create device %s, unexpected error. Error %s \n Response body %s", data.Sn_or_name.String(), err.Error(), BodyToString(&httpRes.Body)),
+			fmt.Sprintf("Could not create device %s, unexpected error. Error %s \n Response body %s", data.Sn_or_name.String(), err.Error(), ResponseBodyToString(httpRes)),
 		)
 		return
 	}
 
-	// Handle the online field, which is remapped to status
-	if !data.Online.IsUnknown() && !data.Online.ValueBool() {
-		statusReq, err := r.setDeviceStatusFromPlan(&resp.Diagnostics, writeOnlyDevice.GetId(), hwmux.DISABLED)
+	// The status is set with a separate request, and only when it is configured
+	status, diags := configuredDeviceStatus(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if status == "" {
+		status = writeOnlyDevice.GetStatus()
+	}
+	if status != writeOnlyDevice.GetStatus() || !data.StatusComment.IsNull() {
+		statusReq, err := r.setDeviceStatusFromPlan(ctx, &resp.Diagnostics, writeOnlyDevice.GetId(), status, statusCommentFromPlan(data.StatusComment))
 		if err != nil {
 			resp.Diagnostics.AddError("Error updating device status", err.Error())
 			return
 		}
 
-		writeOnlyDevice.SetOnline(data.Online.ValueBool())
+		writeOnlyDevice.SetOnline(statusReq.Status == hwmux.ACTIVE)
 		writeOnlyDevice.SetStatus(statusReq.Status)
 	}
 
 	// Map response body to schema and populate Computed attribute values
 	// set model based on response
-	err = updateDeviceModelFromResponse(writeOnlyDevice, data, &resp.Diagnostics, r.client)
+	err = updateDeviceModelFromResponse(ctx, writeOnlyDevice, data, &resp.Diagnostics, r.client)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Updating the device model failed %s", data.Sn_or_name.String()), err.Error(),
@@ -225,9 +273,16 @@ func (r *DeviceResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "read", defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	// Get refreshed device value from hwmux
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	device, _, err := GetDevice(r.client, &resp.Diagnostics, int32(id))
+	var readDiagnostics diag.Diagnostics
+	device, httpRes, err := GetDevice(ctx, r.client, &readDiagnostics, int32(id))
+	if removeResourceIfNotFound(ctx, httpRes, readDiagnostics, resp, "Device") {
+		return
+	}
 	if err != nil {
 		// add diagnostic error with the expected ID
 		resp.Diagnostics.AddError(
@@ -267,8 +322,9 @@ func (r *DeviceResource) Read(ctx context.Context, req resource.ReadRequest, res
 		data.Socketed_chip = types.StringNull()
 	}
 	data.Online = types.BoolValue(device.GetOnline())
+	data.Status = types.StringValue(string(device.GetStatus()))
 
-	location, _, err := GetDeviceLocation(r.client, &resp.Diagnostics, device.GetId())
+	location, _, err := GetDeviceLocation(ctx, r.client, &resp.Diagnostics, device.GetId())
 	if err == nil {
 		data.Room = types.StringValue(location.Room.GetName())
 	}
@@ -278,7 +334,12 @@ func (r *DeviceResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	err = MarshalMetadataSetError(device.GetMetadata(), &resp.Diagnostics, "device", &data.Metadata)
+	err = MarshalMetadataSetError(filterManagedMetadata(device.GetMetadata(), data.ManagedMetadataKeys), &resp.Diagnostics, "device", &data.Metadata)
+	if err != nil {
+		return
+	}
+
+	err = MetadataObjectSetError(filterManagedMetadata(device.GetMetadata(), data.ManagedMetadataKeys), &resp.Diagnostics, "device", &data.MetadataObject)
 	if err != nil {
 		return
 	}
@@ -297,14 +358,19 @@ func (r *DeviceResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data *DeviceResourceModel
+	var state *DeviceResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "update", defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	writeOnlyDevice, err := createDeviceFromPlan(data, &resp.Diagnostics)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -317,37 +383,48 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		writeOnlyDevice.SetSource(hwmux.SOURCEENUM_TERRAFORM)
 	}
 
-	// update device
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	writeOnlyDevice, httpRes, err := r.client.DevicesApi.DevicesUpdate(context.Background(), int32(id)).WriteOnlyDevice(*writeOnlyDevice).Execute()
+
+	// only write the managed metadata keys and keep the other keys set in hwmux
+	if !data.ManagedMetadataKeys.IsNull() && writeOnlyDevice.Metadata != nil {
+		current, _, err := GetDevice(ctx, r.client, &resp.Diagnostics, int32(id))
+		if err != nil {
+			return
+		}
+		writeOnlyDevice.SetMetadata(mergeManagedMetadata(current.GetMetadata(), writeOnlyDevice.GetMetadata(), data.ManagedMetadataKeys))
+	}
+
+	// update device
+	writeOnlyDevice, httpRes, err := r.client.DevicesApi.DevicesUpdate(ctx, int32(id)).WriteOnlyDevice(*writeOnlyDevice).Execute()
 
 	if err != nil {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Error updating device %d", id), fmt.Sprintf("Could not update device %d, unexpected error. Error %s \n Response body %s", id, err.Error(), BodyToString(&httpRes.Body)),
+			fmt.Sprintf("Error updating device %d", id), fmt.Sprintf("Could not update device %d, unexpected error. Error %s \n Response body %s", id, err.Error(), ResponseBodyToString(httpRes)),
 		)
 		return
 	}
 
-	// Handle the online field, which is remapped to status
-	//  will only make a change if there is a difference between the API-provided value and the desired one
-	if (data.Online.IsUnknown() && !writeOnlyDevice.GetOnline()) || (!data.Online.IsUnknown() && data.Online.ValueBool() != writeOnlyDevice.GetOnline()) {
-		status := hwmux.ACTIVE
-		if !data.Online.IsUnknown() && !data.Online.ValueBool() {
-			status = hwmux.DISABLED
-		}
-
-		statusReq, err := r.setDeviceStatusFromPlan(&resp.Diagnostics, writeOnlyDevice.GetId(), status)
+	// The status is set with a separate request
+	//  will only make a change if there is a difference between the API-provided value and the configured one,
+	//  or if the comment changed
+	status, diags := configuredDeviceStatus(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if status == "" {
+		status = writeOnlyDevice.GetStatus()
+	}
+	if status != writeOnlyDevice.GetStatus() || !data.StatusComment.Equal(state.StatusComment) {
+		statusReq, err := r.setDeviceStatusFromPlan(ctx, &resp.Diagnostics, writeOnlyDevice.GetId(), status, statusCommentFromPlan(data.StatusComment))
 		if err != nil {
 			resp.Diagnostics.AddError("Error updating device status", err.Error())
 			return
 		}
 
-		writeOnlyDevice.SetOnline(data.Online.ValueBool())
+		writeOnlyDevice.SetOnline(statusReq.Status == hwmux.ACTIVE)
 		writeOnlyDevice.SetStatus(statusReq.Status)
 	}
 
 	// set model based on response
-	err = updateDeviceModelFromResponse(writeOnlyDevice, data, &resp.Diagnostics, r.client)
+	err = updateDeviceModelFromResponse(ctx, writeOnlyDevice, data, &resp.Diagnostics, r.client)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Updating the device model failed", err.Error(),
@@ -369,45 +446,104 @@ func (r *DeviceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	// if it's offline, set it back online to remove the reservation for the offline status
-	if !data.Online.ValueBool() {
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "delete", defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	// if it's not active, set it back to active to remove the reservation for the offline status
+	if data.Status.ValueString() != string(hwmux.ACTIVE) {
 		id, _ := strconv.Atoi(data.ID.ValueString())
-		r.setDeviceStatusFromPlan(&resp.Diagnostics, int32(id), hwmux.ACTIVE)
+		r.setDeviceStatusFromPlan(ctx, &resp.Diagnostics, int32(id), hwmux.ACTIVE, "Deleted via Terraform")
 	}
 
 	// Delete existing
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	httpRes, err := r.client.DevicesApi.DevicesDestroy(context.Background(), int32(id)).Execute()
+	httpRes, err := r.client.DevicesApi.DevicesDestroy(ctx, int32(id)).Execute()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Device",
-			"Could not delete device, unexpected error: "+BodyToString(&httpRes.Body),
+			"Could not delete device, unexpected error: "+ResponseBodyToString(httpRes),
 		)
 		return
 	}
 }
 
 func (r *DeviceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByIDOrName(ctx, req, resp, "sn_or_name", func(snOrName string) (int32, error) {
+		device, err := GetDeviceBySnOrName(ctx, r.client, &resp.Diagnostics, snOrName)
+		return device.GetId(), err
+	})
 }
 
-func (r *DeviceResource) setDeviceStatusFromPlan(diagnostics *diag.Diagnostics, id int32, status hwmux.StatusEnum) (*hwmux.ResourceStatusRequest, error) {
+func (r *DeviceResource) setDeviceStatusFromPlan(ctx context.Context, diagnostics *diag.Diagnostics, id int32, status hwmux.StatusEnum,
+	comment string) (*hwmux.ResourceStatusRequest, error) {
 
 	statusRequest := hwmux.NewResourceStatusRequestWithDefaults()
-	statusRequest.SetComment("Disabled via Terraform")
+	statusRequest.SetComment(comment)
 	statusRequest.SetStatus(status)
 
-	resourceStatRequest, httpRes, err := r.client.DevicesApi.DevicesStatusCreate(context.Background(), id).ResourceStatusRequest(*statusRequest).Execute()
+	resourceStatRequest, httpRes, err := r.client.DevicesApi.DevicesStatusCreate(ctx, id).ResourceStatusRequest(*statusRequest).Execute()
 	if err != nil {
 		diagnostics.AddError(
 			"Error setting device status "+strconv.Itoa(int(id)),
-			"Could not update device, unexpected error: "+err.Error()+"\n"+BodyToString(&httpRes.Body),
+			"Could not update device, unexpected error: "+err.Error()+"\n"+ResponseBodyToString(httpRes),
 		)
 		return resourceStatRequest, err
 	}
 	return resourceStatRequest, nil
 }
 
+// The status requested by the configuration. The deprecated online attribute maps to ACTIVE or DISABLED.
+// Returns an empty status when neither status nor online is configured, the status of hwmux is then kept.
+func configuredDeviceStatus(ctx context.Context, config tfsdk.Config) (hwmux.StatusEnum, diag.Diagnostics) {
+	var status types.String
+	var online types.Bool
+	diags := config.GetAttribute(ctx, path.Root("status"), &status)
+	diags.Append(config.GetAttribute(ctx, path.Root("online"), &online)...)
+	return desiredDeviceStatus(status, online), diags
+}
+
+func desiredDeviceStatus(status types.String, online types.Bool) hwmux.StatusEnum {
+	if !status.IsUnknown() && !status.IsNull() {
+		return hwmux.StatusEnum(status.ValueString())
+	}
+	if !online.IsUnknown() && !online.IsNull() {
+		if online.ValueBool() {
+			return hwmux.ACTIVE
+		}
+		return hwmux.DISABLED
+	}
+	return ""
+}
+
+// deviceStatusUseStateForUnknown keeps the status of the state when it is not configured, so that updates don't
+// plan a status change. The status is left unknown when it is derived from a different online value.
+type deviceStatusUseStateForUnknown struct{}
+
+func (m deviceStatusUseStateForUnknown) Description(ctx context.Context) string {
+	return "Keeps the prior status when neither status nor online is configured."
+}
+
+func (m deviceStatusUseStateForUnknown) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m deviceStatusUseStateForUnknown) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || !req.ConfigValue.IsNull() || !req.PlanValue.IsUnknown() {
+		return
+	}
+
+	var online types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("online"), &online)...)
+	if online.IsUnknown() {
+		return
+	}
+	if !online.IsNull() && desiredDeviceStatus(types.StringNull(), online) != hwmux.StatusEnum(req.StateValue.ValueString()) {
+		return
+	}
+
+	resp.PlanValue = req.StateValue
+}
+
 // Create a writeOnlyDevice based on a terraform plan
 func createDeviceFromPlan(plan *DeviceResourceModel, diagnostics *diag.Diagnostics) (*hwmux.WriteOnlyDevice, error) {
 	writeOnlyDevice := hwmux.NewWriteOnlyDeviceWithDefaults()
@@ -433,8 +569,12 @@ func createDeviceFromPlan(plan *DeviceResourceModel, diagnostics *diag.Diagnosti
 	} else {
 		writeOnlyDevice.SetUri(plan.Uri.ValueString())
 	}
-	if !plan.Metadata.IsUnknown() {
-		metadata, errorMet := UnmarshalMetadataSetError(plan.Metadata.ValueString(), diagnostics, "device")
+	metadata, errorMet := metadataFromPlan(plan.Metadata, plan.MetadataObject, diagnostics, "device")
+	if errorMet != nil {
+		return nil, errorMet
+	}
+	if metadata != nil {
+		errorMet = checkManagedMetadataKeys(*metadata, plan.ManagedMetadataKeys, diagnostics, "device")
 		if errorMet != nil {
 			return nil, errorMet
 		}
@@ -470,7 +610,7 @@ func createDeviceFromPlan(plan *DeviceResourceModel, diagnostics *diag.Diagnosti
 }
 
 // Map response body to model and populate Computed attribute values
-func updateDeviceModelFromResponse(device *hwmux.WriteOnlyDevice, plan *DeviceResourceModel, diagnostics *diag.Diagnostics,
+func updateDeviceModelFromResponse(ctx context.Context, device *hwmux.WriteOnlyDevice, plan *DeviceResourceModel, diagnostics *diag.Diagnostics,
 	client *hwmux.APIClient) (err error) {
 	// Map response body to schema and populate Computed attribute values
 	plan.ID = types.StringValue(strconv.Itoa(int(device.GetId())))
@@ -492,9 +632,15 @@ func updateDeviceModelFromResponse(device *hwmux.WriteOnlyDevice, plan *DeviceRe
 		plan.Uri = types.StringNull()
 	}
 	plan.Online = types.BoolValue(device.GetOnline())
+	plan.Status = types.StringValue(string(device.GetStatus()))
 	plan.Room = types.StringValue(plan.Room.ValueString())
 
-	err = MarshalMetadataSetError(device.GetMetadata(), diagnostics, "device", &plan.Metadata)
+	err = MarshalMetadataSetError(filterManagedMetadata(device.GetMetadata(), plan.ManagedMetadataKeys), diagnostics, "device", &plan.Metadata)
+	if err != nil {
+		return
+	}
+
+	err = MetadataObjectSetError(filterManagedMetadata(device.GetMetadata(), plan.ManagedMetadataKeys), diagnostics, "device", &plan.MetadataObject)
 	if err != nil {
 		return
 	}
@@ -518,7 +664,7 @@ func updateDeviceModelFromResponse(device *hwmux.WriteOnlyDevice, plan *DeviceRe
 		plan.Socketed_chip = types.StringNull()
 	}
 
-	permissionGroups, err := GetPermissionGroupsForDevice(client, diagnostics, device.GetId())
+	permissionGroups, err := GetPermissionGroupsForDevice(ctx, client, diagnostics, device.GetId())
 	if err != nil {
 		return
 	}
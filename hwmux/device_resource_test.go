@@ -1,8 +1,19 @@
 package hwmux
 
 import (
+	"context"
+	"net/http"
 	"testing"
 
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -50,6 +61,14 @@ resource "hwmux_device" "test" {
 				// API, therefore there is no value for it during import.
 				ImportStateVerifyIgnore: []string{"last_updated"},
 			},
+			// ImportState by name testing
+			{
+				ResourceName:            "hwmux_device.test",
+				ImportState:             true,
+				ImportStateId:           "sn_or_name:test_device",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
 			// Update and Read testing
 			{
 				Config: providerConfig + `
@@ -61,6 +80,9 @@ resource "hwmux_device" "test" {
 	online = false
 	socketed_chip = "BRD1019A002"
     permission_groups = ["All users"]
+	timeouts {
+		update = "10m"
+	}
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
@@ -78,6 +100,7 @@ resource "hwmux_device" "test" {
 					resource.TestCheckResourceAttr("hwmux_device.test", "location_metadata", "{}"),
 					resource.TestCheckResourceAttr("hwmux_device.test", "source", "TERRAFORM"),
 					resource.TestCheckResourceAttr("hwmux_device.test", "socketed_chip", "BRD1019A002"),
+					resource.TestCheckResourceAttr("hwmux_device.test", "timeouts.update", "10m"),
 				),
 			},
 			// (Delete testing automatically occurs in TestCase)
@@ -174,3 +197,232 @@ resource "hwmux_device" "test" {
 		},
 	})
 }
+
+func TestAccDeviceResourceStatus(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "hwmux_device" "test" {
+	sn_or_name = "test_device_status"
+	part = "Part_no_0"
+	room = "Room_0"
+	status = "OFFLINE"
+	status_comment = "Terraform acceptance test"
+	permission_groups = ["Staff users"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hwmux_device.test", "status", "OFFLINE"),
+					resource.TestCheckResourceAttr("hwmux_device.test", "status_comment", "Terraform acceptance test"),
+					resource.TestCheckResourceAttr("hwmux_device.test", "online", "false"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "hwmux_device.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// hwmux does not return the status comment
+				ImportStateVerifyIgnore: []string{"last_updated", "status_comment"},
+			},
+			// The status is kept when it is not configured
+			{
+				Config: providerConfig + `
+resource "hwmux_device" "test" {
+	sn_or_name = "test_device_status"
+	uri = "77.7.7.8"
+	part = "Part_no_0"
+	room = "Room_0"
+	permission_groups = ["Staff users"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hwmux_device.test", "uri", "77.7.7.8"),
+					resource.TestCheckResourceAttr("hwmux_device.test", "status", "OFFLINE"),
+					resource.TestCheckResourceAttr("hwmux_device.test", "online", "false"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "hwmux_device" "test" {
+	sn_or_name = "test_device_status"
+	part = "Part_no_0"
+	room = "Room_0"
+	status = "ACTIVE"
+	permission_groups = ["Staff users"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hwmux_device.test", "status", "ACTIVE"),
+					resource.TestCheckNoResourceAttr("hwmux_device.test", "status_comment"),
+					resource.TestCheckResourceAttr("hwmux_device.test", "online", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestDesiredDeviceStatus(t *testing.T) {
+	cases := map[string]struct {
+		status   types.String
+		online   types.Bool
+		expected hwmux.StatusEnum
+	}{
+		"status":         {types.StringValue("OFFLINE"), types.BoolUnknown(), hwmux.OFFLINE},
+		"online false":   {types.StringUnknown(), types.BoolValue(false), hwmux.DISABLED},
+		"online true":    {types.StringUnknown(), types.BoolValue(true), hwmux.ACTIVE},
+		"not configured": {types.StringUnknown(), types.BoolUnknown(), ""},
+		"null":           {types.StringNull(), types.BoolNull(), ""},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			status := desiredDeviceStatus(tc.status, tc.online)
+			if status != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, status)
+			}
+		})
+	}
+}
+
+func TestDeviceStatusUseStateForUnknown(t *testing.T) {
+	ctx := context.Background()
+	r := NewDeviceResource()
+
+	cases := map[string]struct {
+		online   types.Bool
+		config   types.String
+		expected types.String
+	}{
+		"not configured":    {types.BoolNull(), types.StringNull(), types.StringValue("DISABLED")},
+		"configured":        {types.BoolNull(), types.StringValue("ACTIVE"), types.StringUnknown()},
+		"same online":       {types.BoolValue(false), types.StringNull(), types.StringValue("DISABLED")},
+		"online changes it": {types.BoolValue(true), types.StringNull(), types.StringUnknown()},
+		"online not known":  {types.BoolUnknown(), types.StringNull(), types.StringUnknown()},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			state := newFakeResourceState(t, r, map[string]string{"id": "1", "status": "DISABLED"})
+			state.SetAttribute(ctx, path.Root("online"), tc.online)
+			state.SetAttribute(ctx, path.Root("status"), tc.config)
+
+			req := planmodifier.StringRequest{
+				Path:        path.Root("status"),
+				Config:      tfsdk.Config{Schema: state.Schema, Raw: state.Raw},
+				ConfigValue: tc.config,
+				PlanValue:   types.StringUnknown(),
+				StateValue:  types.StringValue("DISABLED"),
+			}
+			if !tc.config.IsNull() {
+				req.PlanValue = tc.config
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+			deviceStatusUseStateForUnknown{}.PlanModifyString(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error diagnostics: %v", resp.Diagnostics)
+			}
+			if !tc.config.IsNull() {
+				tc.expected = tc.config
+			}
+			if !resp.PlanValue.Equal(tc.expected) {
+				t.Errorf("expected %s, got %s", tc.expected, resp.PlanValue)
+			}
+		})
+	}
+}
+
+func TestDeviceUpdateKeepsUnconfiguredStatus(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case req.Method == http.MethodPut && req.URL.Path == "/api/devices/1/":
+			w.Write([]byte(`{"id": 1, "sn_or_name": "dev", "part": "Part_no_0", "room": "Room_0", "status": "OFFLINE", "online": false, "metadata": {}}`))
+		case req.Method == http.MethodGet:
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("the status set in hwmux must be kept, got %s %s", req.Method, req.URL)
+		}
+	})
+
+	r := NewDeviceResource()
+	r.(tfresource.ResourceWithConfigure).Configure(ctx, tfresource.ConfigureRequest{ProviderData: client}, &tfresource.ConfigureResponse{})
+
+	schemaResp := &tfresource.SchemaResponse{}
+	r.Schema(ctx, tfresource.SchemaRequest{}, schemaResp)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	timeoutsType := schemaResp.Schema.Blocks["timeouts"].Type().(timeouts.Type)
+	model := &DeviceResourceModel{
+		ID:                  types.StringValue("1"),
+		Sn_or_name:          types.StringValue("dev"),
+		Is_wstk:             types.BoolUnknown(),
+		Uri:                 types.StringUnknown(),
+		Online:              types.BoolNull(),
+		Status:              types.StringNull(),
+		StatusComment:       types.StringNull(),
+		Metadata:            types.StringUnknown(),
+		MetadataObject:      types.MapUnknown(types.StringType),
+		ManagedMetadataKeys: types.SetNull(types.StringType),
+		Part:                types.StringValue("Part_no_0"),
+		Wstk_part:           types.StringNull(),
+		Room:                types.StringValue("Room_0"),
+		LocationMetadata:    types.StringUnknown(),
+		PermissionGroups:    []types.String{},
+		LastUpdated:         types.StringUnknown(),
+		Source:              types.StringUnknown(),
+		Socketed_chip:       types.StringNull(),
+		Timeouts:            timeouts.Value{Object: types.ObjectNull(timeoutsType.AttrTypes)},
+	}
+	diags := plan.Set(ctx, model)
+	config := tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}
+
+	// the planned status comes from the state
+	model.Status = types.StringValue("OFFLINE")
+	model.Online = types.BoolUnknown()
+	diags.Append(plan.Set(ctx, model)...)
+	if diags.HasError() {
+		t.Fatalf("unable to build the plan: %v", diags)
+	}
+
+	state := tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}
+	resp := &tfresource.UpdateResponse{State: state}
+	r.Update(ctx, tfresource.UpdateRequest{Plan: plan, Config: config, State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var status string
+	resp.State.GetAttribute(ctx, path.Root("status"), &status)
+	if status != "OFFLINE" {
+		t.Errorf("expected the status to be kept, got %s", status)
+	}
+}
+
+func TestDeviceLocationMetadataSemanticEquality(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &tfresource.SchemaResponse{}
+	NewDeviceResource().Schema(ctx, tfresource.SchemaRequest{}, schemaResp)
+
+	for _, name := range []string{"metadata", "location_metadata"} {
+		t.Run(name, func(t *testing.T) {
+			config := types.StringValue(`{"shelf": 2, "rack": "A"}`)
+			state := types.StringValue(`{"rack":"A","shelf":2}`)
+			req := planmodifier.StringRequest{Path: path.Root(name), ConfigValue: config, PlanValue: config, StateValue: state}
+			resp := &planmodifier.StringResponse{PlanValue: config}
+			for _, modifier := range schemaResp.Schema.Attributes[name].(schema.StringAttribute).PlanModifiers {
+				modifier.PlanModifyString(ctx, req, resp)
+			}
+
+			if !resp.PlanValue.Equal(state) {
+				t.Errorf("expected the reformatted json not to plan an update, got %s", resp.PlanValue)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/Silabs-UTF/hwmux-client-golang/v2"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -31,6 +32,7 @@ type DeviceGroupDataSourceModel struct {
 	Enable_ahs_actions types.Bool          `tfsdk:"enable_ahs_actions"`
 	Metadata           types.String        `tfsdk:"metadata"`
 	Source             types.String        `tfsdk:"source"`
+	Permissions        types.Object        `tfsdk:"permissions"`
 }
 
 type nestedDeviceModel struct {
@@ -87,6 +89,7 @@ func (d *DeviceGroupDataSource) Schema(ctx context.Context, req datasource.Schem
 				MarkdownDescription: "The source where the device group was created.",
 				Computed:            true,
 			},
+			"permissions": objectPermissionsDataSourceAttribute("device group"),
 		},
 	}
 }
@@ -121,12 +124,32 @@ func (d *DeviceGroupDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	deviceGroup, _, err := GetDeviceGroup(d.client, &resp.Diagnostics, int32(data.ID.ValueInt64()))
+	deviceGroup, _, err := GetDeviceGroup(ctx, d.client, &resp.Diagnostics, int32(data.ID.ValueInt64()))
 	if err != nil {
 		return
 	}
 
-	// Map response body to model
+	err = updateDeviceGroupDataSourceModelFromResponse(deviceGroup, &data, &resp.Diagnostics)
+	if err != nil {
+		return
+	}
+
+	objectPerms, _, err := GetObjectPermissions(ctx, d.client, &resp.Diagnostics, "device_group", deviceGroup.GetId())
+	if err != nil {
+		return
+	}
+	data.Permissions, err = objectPermissionsValue(ctx, objectPerms, &resp.Diagnostics)
+	if err != nil {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Map response body to the data source model
+func updateDeviceGroupDataSourceModelFromResponse(deviceGroup *hwmux.DeviceGroup, data *DeviceGroupDataSourceModel,
+	diagnostics *diag.Diagnostics) error {
 	data.ID = types.Int64Value(int64(deviceGroup.GetId()))
 	data.Name = types.StringValue(deviceGroup.GetName())
 	data.Enable_ahs = types.BoolValue(deviceGroup.GetEnableAhs())
@@ -134,9 +157,9 @@ func (d *DeviceGroupDataSource) Read(ctx context.Context, req datasource.ReadReq
 	data.Enable_ahs_cas = types.BoolValue(deviceGroup.GetEnableAhsCas())
 	data.Source = types.StringValue(string(deviceGroup.GetSource()))
 
-	err = MarshalMetadataSetError(deviceGroup.GetMetadata(), &resp.Diagnostics, "deviceGroup", &data.Metadata)
+	err := MarshalMetadataSetError(deviceGroup.GetMetadata(), diagnostics, "deviceGroup", &data.Metadata)
 	if err != nil {
-		return
+		return err
 	}
 
 	data.Devices = make([]nestedDeviceModel, len(deviceGroup.GetDevices()))
@@ -144,6 +167,8 @@ func (d *DeviceGroupDataSource) Read(ctx context.Context, req datasource.ReadReq
 		data.Devices[i] = nestedDeviceModel{ID: types.Int64Value(int64(device.GetId()))}
 	}
 
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	// the permissions are read separately, only when they are requested
+	data.Permissions = types.ObjectNull(objectPermissionsAttrTypes)
+
+	return nil
 }
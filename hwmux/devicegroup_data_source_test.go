@@ -23,6 +23,8 @@ func TestAccDeviceGroupDataSource(t *testing.T) {
 					resource.TestCheckResourceAttrSet(deviceGroupDataSourceTfName, "enable_ahs"),
 					resource.TestCheckResourceAttrSet(deviceGroupDataSourceTfName, "enable_ahs_actions"),
 					resource.TestCheckResourceAttrSet(deviceGroupDataSourceTfName, "enable_ahs_cas"),
+					resource.TestCheckResourceAttrSet(deviceGroupDataSourceTfName, "permissions.user_groups.%"),
+					resource.TestCheckResourceAttrSet(deviceGroupDataSourceTfName, "permissions.users.%"),
 					resource.TestCheckResourceAttrSet(deviceGroupDataSourceTfName, "metadata"),
 					resource.TestCheckResourceAttrSet(deviceGroupDataSourceTfName, "devices.0.id"),
 					resource.TestCheckResourceAttrSet(deviceGroupDataSourceTfName, "source"),
@@ -0,0 +1,252 @@
+package hwmux
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &DeviceGroupMembershipResource{}
+var _ resource.ResourceWithImportState = &DeviceGroupMembershipResource{}
+
+func NewDeviceGroupMembershipResource() resource.Resource {
+	return &DeviceGroupMembershipResource{}
+}
+
+// DeviceGroupMembershipResource defines the resource implementation.
+type DeviceGroupMembershipResource struct {
+	client *hwmux.APIClient
+}
+
+// DeviceGroupMembershipResourceModel describes the resource data model.
+type DeviceGroupMembershipResourceModel struct {
+	ID              types.String   `tfsdk:"id"`
+	Device_group_id types.Int64    `tfsdk:"device_group_id"`
+	Device_id       types.Int64    `tfsdk:"device_id"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *DeviceGroupMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_group_membership"
+}
+
+func (r *DeviceGroupMembershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Device group membership resource. Adds a single device to a device group without managing " +
+			"the other devices of the group, so that several configurations can contribute devices to the same group. " +
+			"The `devices` attribute of the `hwmux_device_group` must be omitted when its devices are managed with memberships.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Membership identifier, formatted as `device_group_id/device_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"device_group_id": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the device group.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"device_id": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the device to add to the device group.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *DeviceGroupMembershipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hwmux.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *hwmux.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DeviceGroupMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *DeviceGroupMembershipResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "create", defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	groupId := int32(data.Device_group_id.ValueInt64())
+	deviceId := int32(data.Device_id.ValueInt64())
+
+	_, err := r.updateDeviceGroupDevices(ctx, &resp.Diagnostics, groupId, func(devices []int32) []int32 {
+		for _, device := range devices {
+			if device == deviceId {
+				return devices
+			}
+		}
+		return append(devices, deviceId)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error adding device %d to device group %d", deviceId, groupId),
+			fmt.Sprintf("Could not add device %d to device group %d, unexpected error: %s", deviceId, groupId, err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%d/%d", groupId, deviceId))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeviceGroupMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *DeviceGroupMembershipResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "read", defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	groupId := int32(data.Device_group_id.ValueInt64())
+	deviceId := int32(data.Device_id.ValueInt64())
+
+	// Get refreshed device group value from hwmux
+	var readDiagnostics diag.Diagnostics
+	deviceGroup, httpRes, err := GetDeviceGroup(ctx, r.client, &readDiagnostics, groupId)
+	if removeResourceIfNotFound(ctx, httpRes, readDiagnostics, resp, "Device Group") {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error reading device group %d", groupId),
+			fmt.Sprintf("Could not read device group %d, unexpected error: %s", groupId, err.Error()),
+		)
+		return
+	}
+
+	// the membership is gone if the device was removed from the group
+	for _, device := range deviceGroup.GetDevices() {
+		if device.GetId() == deviceId {
+			data.ID = types.StringValue(fmt.Sprintf("%d/%d", groupId, deviceId))
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *DeviceGroupMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	updateTimeoutsOnly(req, resp)
+}
+
+func (r *DeviceGroupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *DeviceGroupMembershipResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "delete", defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	groupId := int32(data.Device_group_id.ValueInt64())
+	deviceId := int32(data.Device_id.ValueInt64())
+
+	var deleteDiagnostics diag.Diagnostics
+	httpRes, err := r.updateDeviceGroupDevices(ctx, &deleteDiagnostics, groupId, func(devices []int32) []int32 {
+		remaining := make([]int32, 0, len(devices))
+		for _, device := range devices {
+			if device != deviceId {
+				remaining = append(remaining, device)
+			}
+		}
+		return remaining
+	})
+	// nothing to remove if the device group was deleted
+	if IsNotFound(httpRes) {
+		return
+	}
+	resp.Diagnostics.Append(deleteDiagnostics...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error removing device %d from device group %d", deviceId, groupId),
+			fmt.Sprintf("Could not remove device %d from device group %d, unexpected error: %s", deviceId, groupId, err.Error()),
+		)
+		return
+	}
+}
+
+func (r *DeviceGroupMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateByCompositeID(ctx, req, resp, "device_group_id", "device_id")
+}
+
+// Read the devices of the device group, update them with the given function and write them back to hwmux.
+// Returns the response of the failed request on error.
+func (r *DeviceGroupMembershipResource) updateDeviceGroupDevices(ctx context.Context, diagnostics *diag.Diagnostics, groupId int32,
+	update func(devices []int32) []int32) (*http.Response, error) {
+	unlock := lockObject(fmt.Sprintf("device_group/%d", groupId))
+	defer unlock()
+
+	deviceGroup, httpRes, err := GetDeviceGroup(ctx, r.client, diagnostics, groupId)
+	if err != nil {
+		return httpRes, err
+	}
+
+	devices := make([]int32, len(deviceGroup.GetDevices()))
+	for i, device := range deviceGroup.GetDevices() {
+		devices[i] = device.GetId()
+	}
+
+	patchedDeviceGroup := hwmux.NewPatchedDeviceGroupSerializerWithDevicePk()
+	patchedDeviceGroup.SetDevices(update(devices))
+
+	_, httpRes, err = r.client.GroupsApi.GroupsPartialUpdate(ctx, groupId).PatchedDeviceGroupSerializerWithDevicePk(*patchedDeviceGroup).Execute()
+	if err != nil {
+		return httpRes, fmt.Errorf("%s%s", err.Error(), ResponseBodyToString(httpRes))
+	}
+	return httpRes, nil
+}
@@ -0,0 +1,110 @@
+package hwmux
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDeviceGroupMembershipResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "hwmux_device_group" "test" {
+	name              = "test_dg_membership"
+	permission_groups = ["All users"]
+}
+
+resource "hwmux_device_group_membership" "test" {
+	device_group_id = hwmux_device_group.test.id
+	device_id       = 1
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("hwmux_device_group_membership.test", "device_group_id", deviceGroupResourceTfName, "id"),
+					resource.TestCheckResourceAttr("hwmux_device_group_membership.test", "device_id", "1"),
+					resource.TestCheckResourceAttrSet("hwmux_device_group_membership.test", "id"),
+					resource.TestCheckNoResourceAttr(deviceGroupResourceTfName, "devices.#"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "hwmux_device_group_membership.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestDeviceGroupMembershipConcurrentCreates(t *testing.T) {
+	ctx := context.Background()
+	var lock sync.Mutex
+	devices := []int32{}
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if req.Method == http.MethodPatch {
+			var body struct {
+				Devices []int32 `json:"devices"`
+			}
+			json.NewDecoder(req.Body).Decode(&body)
+			devices = body.Devices
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "devices": devices})
+			return
+		}
+		lightDevices := make([]map[string]int32, len(devices))
+		for i, device := range devices {
+			lightDevices[i] = map[string]int32{"id": device}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "devices": lightDevices})
+	})
+
+	r := NewDeviceGroupMembershipResource()
+	r.(tfresource.ResourceWithConfigure).Configure(ctx, tfresource.ConfigureRequest{ProviderData: client}, &tfresource.ConfigureResponse{})
+	schemaResp := &tfresource.SchemaResponse{}
+	r.Schema(ctx, tfresource.SchemaRequest{}, schemaResp)
+	timeoutsType := schemaResp.Schema.Blocks["timeouts"].Type().(timeouts.Type)
+
+	var wg sync.WaitGroup
+	for device := 1; device <= 10; device++ {
+		wg.Add(1)
+		go func(device int64) {
+			defer wg.Done()
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			plan.Set(ctx, &DeviceGroupMembershipResourceModel{
+				ID:              types.StringUnknown(),
+				Device_group_id: types.Int64Value(1),
+				Device_id:       types.Int64Value(device),
+				Timeouts:        timeouts.Value{Object: types.ObjectNull(timeoutsType.AttrTypes)},
+			})
+			resp := &tfresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+			r.Create(ctx, tfresource.CreateRequest{Plan: plan}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Errorf("unexpected error adding device %d: %v", device, resp.Diagnostics)
+			}
+		}(int64(device))
+	}
+	wg.Wait()
+
+	sort.Slice(devices, func(i, j int) bool { return devices[i] < devices[j] })
+	if fmt.Sprint(devices) != "[1 2 3 4 5 6 7 8 9 10]" {
+		t.Errorf("expected every device to be added to the group, got %v", devices)
+	}
+}
@@ -5,12 +5,13 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -34,16 +35,22 @@ type DeviceGroupResource struct {
 
 // DeviceGroupResourceModel describes the resource data model.
 type DeviceGroupResourceModel struct {
-	ID                 types.String   `tfsdk:"id"`
-	Name               types.String   `tfsdk:"name"`
-	Metadata           types.String   `tfsdk:"metadata"`
-	Devices            []types.Int64  `tfsdk:"devices"`
-	PermissionGroups   []types.String `tfsdk:"permission_groups"`
-	Enable_ahs         types.Bool     `tfsdk:"enable_ahs"`
-	Enable_ahs_actions types.Bool     `tfsdk:"enable_ahs_actions"`
-	LastUpdated        types.String   `tfsdk:"last_updated"`
-	Enable_ahs_cas     types.Bool     `tfsdk:"enable_ahs_cas"`
-	Source             types.String   `tfsdk:"source"`
+	ID                  types.String   `tfsdk:"id"`
+	Name                types.String   `tfsdk:"name"`
+	Metadata            types.String   `tfsdk:"metadata"`
+	MetadataObject      types.Map      `tfsdk:"metadata_object"`
+	ManagedMetadataKeys types.Set      `tfsdk:"managed_metadata_keys"`
+	Devices             []types.Int64  `tfsdk:"devices"`
+	PermissionGroups    []types.String `tfsdk:"permission_groups"`
+	Enable_ahs          types.Bool     `tfsdk:"enable_ahs"`
+	Enable_ahs_actions  types.Bool     `tfsdk:"enable_ahs_actions"`
+	LastUpdated         types.String   `tfsdk:"last_updated"`
+	Enable_ahs_cas      types.Bool     `tfsdk:"enable_ahs_cas"`
+	Source              types.String   `tfsdk:"source"`
+	Online              types.Bool     `tfsdk:"online"`
+	Status              types.String   `tfsdk:"status"`
+	StatusComment       types.String   `tfsdk:"status_comment"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *DeviceGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -76,11 +83,19 @@ func (r *DeviceGroupResource) Schema(ctx context.Context, req resource.SchemaReq
 				MarkdownDescription: "The metadata of the Device Group.",
 				Computed:            true,
 				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					metadataSemanticEquality{},
+				},
 			},
+			"metadata_object":       metadataObjectAttribute("device group"),
+			"managed_metadata_keys": managedMetadataKeysAttribute("device group"),
 			"devices": schema.SetAttribute{
-				MarkdownDescription: "The devices that belong to the Device Group.",
-				Required:            true,
-				ElementType:         types.Int64Type,
+				MarkdownDescription: "The devices that belong to the Device Group. When set, the list is authoritative and devices " +
+					"added to the group outside of this resource are removed. Omit it to manage the devices of the group with " +
+					"`hwmux_device_group_membership` resources instead; the two styles must not be mixed for the same group. " +
+					"Imported device groups don't track their devices, the first apply that sets `devices` replaces them.",
+				Optional:    true,
+				ElementType: types.Int64Type,
 			},
 			"permission_groups": schema.SetAttribute{
 				MarkdownDescription: "Which permission groups can access the resource.",
@@ -110,6 +125,30 @@ func (r *DeviceGroupResource) Schema(ctx context.Context, req resource.SchemaReq
 				Description: "The source where the device group was created.",
 				Computed:    true,
 			},
+			"online": schema.BoolAttribute{
+				MarkdownDescription: "If the Device Group is online, which is the case when its `status` is `ACTIVE`.",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The status of the Device Group, one of %s. The status set in hwmux, "+
+					"for instance by the Automated Health Service, is kept when it is not configured.", strings.Join(statusEnumValues(), ", ")),
+				Computed: true,
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(statusEnumValues()...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status_comment": schema.StringAttribute{
+				MarkdownDescription: "The reason of the status, recorded in hwmux when the status is set. " +
+					"hwmux does not return it, so changes made outside of Terraform are not detected.",
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
 		},
 	}
 }
@@ -144,6 +183,9 @@ func (r *DeviceGroupResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "create", defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	deviceGroupSerializer, err := createDeviceGroupFromPlan(data, &resp.Diagnostics)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -153,19 +195,31 @@ func (r *DeviceGroupResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	// create new deviceGroup
-	deviceGroupSerializer, httpRes, err := r.client.GroupsApi.GroupsCreate(context.Background()).DeviceGroupSerializerWithDevicePk(*deviceGroupSerializer).Execute()
+	deviceGroupSerializer, httpRes, err := r.client.GroupsApi.GroupsCreate(ctx).DeviceGroupSerializerWithDevicePk(*deviceGroupSerializer).Execute()
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Error creating deviceGroup %s", data.Name.String()),
-			fmt.Sprintf("Could not create deviceGroup %s, unexpected error: %s\n%s", data.Name.String(), err.Error(), BodyToString(&httpRes.Body)),
+			fmt.Sprintf("Could not create deviceGroup %s, unexpected error: %s\n%s", data.Name.String(), err.Error(), ResponseBodyToString(httpRes)),
 		)
 		return
 	}
 
+	// The status is set with a separate request
+	if !data.Status.IsUnknown() && (data.Status.ValueString() != deviceGroupSerializer.GetStatus() || !data.StatusComment.IsNull()) {
+		statusReq, err := r.setDeviceGroupStatus(ctx, &resp.Diagnostics, deviceGroupSerializer.GetId(),
+			hwmux.StatusEnum(data.Status.ValueString()), statusCommentFromPlan(data.StatusComment))
+		if err != nil {
+			return
+		}
+
+		deviceGroupSerializer.SetOnline(statusReq.Status == hwmux.ACTIVE)
+		deviceGroupSerializer.SetStatus(string(statusReq.Status))
+	}
+
 	// Map response body to schema and populate Computed attribute values
 	// set model based on response
-	err = updateDGModelFromResponse(deviceGroupSerializer, data, &resp.Diagnostics, r.client)
+	err = updateDGModelFromResponse(ctx, deviceGroupSerializer, data, &resp.Diagnostics, r.client)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Updating the deviceGroup model failed", err.Error(),
@@ -187,9 +241,16 @@ func (r *DeviceGroupResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "read", defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	// Get refreshed deviceGroup value from hwmux
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	deviceGroup, _, err := GetDeviceGroup(r.client, &resp.Diagnostics, int32(id))
+	var readDiagnostics diag.Diagnostics
+	deviceGroup, httpRes, err := GetDeviceGroup(ctx, r.client, &readDiagnostics, int32(id))
+	if removeResourceIfNotFound(ctx, httpRes, readDiagnostics, resp, "Device Group") {
+		return
+	}
 	if err != nil {
 		// add diagnostic error with the expected ID
 		resp.Diagnostics.AddError(
@@ -206,15 +267,25 @@ func (r *DeviceGroupResource) Read(ctx context.Context, req resource.ReadRequest
 	data.Enable_ahs_actions = types.BoolValue(deviceGroup.GetEnableAhsActions())
 	data.Enable_ahs_cas = types.BoolValue(deviceGroup.GetEnableAhsCas())
 	data.Source = types.StringValue(string(deviceGroup.GetSource()))
+	data.Online = types.BoolValue(deviceGroup.GetOnline())
+	data.Status = types.StringValue(deviceGroup.GetStatus())
 
-	err = MarshalMetadataSetError(deviceGroup.GetMetadata(), &resp.Diagnostics, "Device Group", &data.Metadata)
+	err = MarshalMetadataSetError(filterManagedMetadata(deviceGroup.GetMetadata(), data.ManagedMetadataKeys), &resp.Diagnostics, "Device Group", &data.Metadata)
 	if err != nil {
 		return
 	}
 
-	data.Devices = make([]types.Int64, len(deviceGroup.GetDevices()))
-	for i, device := range deviceGroup.GetDevices() {
-		data.Devices[i] = types.Int64Value(int64(device.GetId()))
+	err = MetadataObjectSetError(filterManagedMetadata(deviceGroup.GetMetadata(), data.ManagedMetadataKeys), &resp.Diagnostics, "Device Group", &data.MetadataObject)
+	if err != nil {
+		return
+	}
+
+	// the devices are only tracked when they are managed by this resource
+	if data.Devices != nil {
+		data.Devices = make([]types.Int64, len(deviceGroup.GetDevices()))
+		for i, device := range deviceGroup.GetDevices() {
+			data.Devices[i] = types.Int64Value(int64(device.GetId()))
+		}
 	}
 
 	permissionGroups := deviceGroup.GetPermissionGroups()
@@ -229,14 +300,19 @@ func (r *DeviceGroupResource) Read(ctx context.Context, req resource.ReadRequest
 
 func (r *DeviceGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data *DeviceGroupResourceModel
+	var state *DeviceGroupResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "update", defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	deviceGroupSerializer, err := createDeviceGroupFromPlan(data, &resp.Diagnostics)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -245,20 +321,44 @@ func (r *DeviceGroupResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	// update deviceGroup
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	deviceGroupSerializer, httpRes, err := r.client.GroupsApi.GroupsUpdate(context.Background(), int32(id)).DeviceGroupSerializerWithDevicePk(*deviceGroupSerializer).Execute()
+
+	// only write the managed metadata keys and keep the other keys set in hwmux
+	if !data.ManagedMetadataKeys.IsNull() && deviceGroupSerializer.Metadata != nil {
+		current, _, err := GetDeviceGroup(ctx, r.client, &resp.Diagnostics, int32(id))
+		if err != nil {
+			return
+		}
+		deviceGroupSerializer.SetMetadata(mergeManagedMetadata(current.GetMetadata(), deviceGroupSerializer.GetMetadata(), data.ManagedMetadataKeys))
+	}
+
+	// update deviceGroup
+	deviceGroupSerializer, httpRes, err := r.client.GroupsApi.GroupsUpdate(ctx, int32(id)).DeviceGroupSerializerWithDevicePk(*deviceGroupSerializer).Execute()
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Error updating deviceGroup %d", id),
-			fmt.Sprintf("Could not update deviceGroup %d, unexpected error: %s\n%s", id, err.Error(), BodyToString(&httpRes.Body)),
+			fmt.Sprintf("Could not update deviceGroup %d, unexpected error: %s\n%s", id, err.Error(), ResponseBodyToString(httpRes)),
 		)
 		return
 	}
 
+	// The status is set with a separate request
+	//  will only make a change if there is a difference between the API-provided value and the desired one,
+	//  or if the comment changed
+	if !data.Status.IsUnknown() && (data.Status.ValueString() != deviceGroupSerializer.GetStatus() || !data.StatusComment.Equal(state.StatusComment)) {
+		statusReq, err := r.setDeviceGroupStatus(ctx, &resp.Diagnostics, deviceGroupSerializer.GetId(),
+			hwmux.StatusEnum(data.Status.ValueString()), statusCommentFromPlan(data.StatusComment))
+		if err != nil {
+			return
+		}
+
+		deviceGroupSerializer.SetOnline(statusReq.Status == hwmux.ACTIVE)
+		deviceGroupSerializer.SetStatus(string(statusReq.Status))
+	}
+
 	// set model based on response
-	err = updateDGModelFromResponse(deviceGroupSerializer, data, &resp.Diagnostics, r.client)
+	err = updateDGModelFromResponse(ctx, deviceGroupSerializer, data, &resp.Diagnostics, r.client)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Updating the deviceGroup model failed", err.Error(),
@@ -280,20 +380,45 @@ func (r *DeviceGroupResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "delete", defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	// Delete existing
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	httpRes, err := r.client.GroupsApi.GroupsDestroy(context.Background(), int32(id)).Execute()
+	httpRes, err := r.client.GroupsApi.GroupsDestroy(ctx, int32(id)).Execute()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Error deleting deviceGroup %s", data.ID.String()),
-			fmt.Sprintf("Could not delete deviceGroup %s, unexpected error: %s\n%s", data.ID.String(), err.Error(), BodyToString(&httpRes.Body)),
+			fmt.Sprintf("Could not delete deviceGroup %s, unexpected error: %s\n%s", data.ID.String(), err.Error(), ResponseBodyToString(httpRes)),
 		)
 		return
 	}
 }
 
 func (r *DeviceGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// devices is left unset, so imported device groups don't track their devices until the configuration sets them
+	importStateByIDOrName(ctx, req, resp, "name", func(name string) (int32, error) {
+		deviceGroup, err := GetDeviceGroupByName(ctx, r.client, &resp.Diagnostics, name)
+		return deviceGroup.GetId(), err
+	})
+}
+
+func (r *DeviceGroupResource) setDeviceGroupStatus(ctx context.Context, diagnostics *diag.Diagnostics, id int32, status hwmux.StatusEnum,
+	comment string) (*hwmux.ResourceStatusRequest, error) {
+
+	statusRequest := hwmux.NewResourceStatusRequestWithDefaults()
+	statusRequest.SetComment(comment)
+	statusRequest.SetStatus(status)
+
+	resourceStatRequest, httpRes, err := r.client.GroupsApi.GroupsStatusCreate(ctx, id).ResourceStatusRequest(*statusRequest).Execute()
+	if err != nil {
+		diagnostics.AddError(
+			"Error setting deviceGroup status "+strconv.Itoa(int(id)),
+			fmt.Sprintf("Could not set the status of deviceGroup %d, unexpected error: %s\n%s", id, err.Error(), ResponseBodyToString(httpRes)),
+		)
+		return resourceStatRequest, err
+	}
+	return resourceStatRequest, nil
 }
 
 func createDeviceGroupFromPlan(plan *DeviceGroupResourceModel, diagnostics *diag.Diagnostics) (*hwmux.DeviceGroupSerializerWithDevicePk, error) {
@@ -310,20 +435,26 @@ func createDeviceGroupFromPlan(plan *DeviceGroupResourceModel, diagnostics *diag
 		deviceGroupSerializer.SetEnableAhsCas(plan.Enable_ahs_cas.ValueBool())
 	}
 
-	if !plan.Metadata.IsUnknown() {
-		metadata, errorMet := UnmarshalMetadataSetError(plan.Metadata.ValueString(), diagnostics, "deviceGroup")
+	metadata, errorMet := metadataFromPlan(plan.Metadata, plan.MetadataObject, diagnostics, "deviceGroup")
+	if errorMet != nil {
+		return nil, errorMet
+	}
+	if metadata != nil {
+		errorMet = checkManagedMetadataKeys(*metadata, plan.ManagedMetadataKeys, diagnostics, "device group")
 		if errorMet != nil {
 			return nil, errorMet
 		}
 		deviceGroupSerializer.SetMetadata(*metadata)
 	}
 
-	deviceIds := make([]int32, len(plan.Devices))
-	for i, device := range plan.Devices {
-		deviceIds[i] = int32(device.ValueInt64())
-	}
+	if plan.Devices != nil {
+		deviceIds := make([]int32, len(plan.Devices))
+		for i, device := range plan.Devices {
+			deviceIds[i] = int32(device.ValueInt64())
+		}
 
-	deviceGroupSerializer.SetDevices(deviceIds)
+		deviceGroupSerializer.SetDevices(deviceIds)
+	}
 
 	permissionList := make([]string, len(plan.PermissionGroups))
 	for i, permissionGroup := range plan.PermissionGroups {
@@ -336,7 +467,7 @@ func createDeviceGroupFromPlan(plan *DeviceGroupResourceModel, diagnostics *diag
 }
 
 // Map response body to model and populate Computed attribute values
-func updateDGModelFromResponse(deviceGroup *hwmux.DeviceGroupSerializerWithDevicePk, plan *DeviceGroupResourceModel, diagnostics *diag.Diagnostics, client *hwmux.APIClient) (err error) {
+func updateDGModelFromResponse(ctx context.Context, deviceGroup *hwmux.DeviceGroupSerializerWithDevicePk, plan *DeviceGroupResourceModel, diagnostics *diag.Diagnostics, client *hwmux.APIClient) (err error) {
 	// Map response body to schema and populate Computed attribute values
 	plan.ID = types.StringValue(strconv.Itoa(int(deviceGroup.GetId())))
 	plan.Name = types.StringValue(deviceGroup.GetName())
@@ -344,18 +475,27 @@ func updateDGModelFromResponse(deviceGroup *hwmux.DeviceGroupSerializerWithDevic
 	plan.Enable_ahs_actions = types.BoolValue(deviceGroup.GetEnableAhsActions())
 	plan.Enable_ahs_cas = types.BoolValue(deviceGroup.GetEnableAhsCas())
 	plan.Source = types.StringValue(string(deviceGroup.GetSource()))
+	plan.Online = types.BoolValue(deviceGroup.GetOnline())
+	plan.Status = types.StringValue(deviceGroup.GetStatus())
+
+	err = MarshalMetadataSetError(filterManagedMetadata(deviceGroup.GetMetadata(), plan.ManagedMetadataKeys), diagnostics, "deviceGroup", &plan.Metadata)
+	if err != nil {
+		return
+	}
 
-	err = MarshalMetadataSetError(deviceGroup.GetMetadata(), diagnostics, "deviceGroup", &plan.Metadata)
+	err = MetadataObjectSetError(filterManagedMetadata(deviceGroup.GetMetadata(), plan.ManagedMetadataKeys), diagnostics, "deviceGroup", &plan.MetadataObject)
 	if err != nil {
 		return
 	}
 
-	plan.Devices = make([]types.Int64, len(deviceGroup.GetDevices()))
-	for i, device := range deviceGroup.GetDevices() {
-		plan.Devices[i] = types.Int64Value(int64(device))
+	if plan.Devices != nil {
+		plan.Devices = make([]types.Int64, len(deviceGroup.GetDevices()))
+		for i, device := range deviceGroup.GetDevices() {
+			plan.Devices[i] = types.Int64Value(int64(device))
+		}
 	}
 
-	permissionGroups, err := GetPermissionGroupsForDeviceGroup(client, diagnostics, deviceGroup.GetId())
+	permissionGroups, err := GetPermissionGroupsForDeviceGroup(ctx, client, diagnostics, deviceGroup.GetId())
 	if err != nil {
 		return
 	}
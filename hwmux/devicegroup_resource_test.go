@@ -1,8 +1,19 @@
 package hwmux
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -30,6 +41,8 @@ resource "hwmux_device_group" "test" {
 					// Verify the device_group item has Computed attributes filled.
 					resource.TestCheckResourceAttr(deviceGroupResourceTfName, "metadata", "{}"),
 					resource.TestCheckResourceAttr(deviceGroupResourceTfName, "source", "TERRAFORM"),
+					resource.TestCheckResourceAttr(deviceGroupResourceTfName, "status", "ACTIVE"),
+					resource.TestCheckResourceAttr(deviceGroupResourceTfName, "online", "true"),
 					// Verify dynamic values have any value set in the state.
 					resource.TestCheckResourceAttrSet(deviceGroupResourceTfName, "id"),
 					resource.TestCheckResourceAttrSet(deviceGroupResourceTfName, "enable_ahs"),
@@ -43,8 +56,17 @@ resource "hwmux_device_group" "test" {
 				ImportState:       true,
 				ImportStateVerify: true,
 				// The last_updated attribute does not exist in the HashiCups
-				// API, therefore there is no value for it during import.
-				ImportStateVerifyIgnore: []string{"last_updated"},
+				// API, therefore there is no value for it during import. Imported
+				// device groups don't track their devices.
+				ImportStateVerifyIgnore: []string{"last_updated", "devices"},
+			},
+			// ImportState by name testing
+			{
+				ResourceName:            deviceGroupResourceTfName,
+				ImportState:             true,
+				ImportStateId:           "name:test_dg",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "devices"},
 			},
 			// Update and Read testing
 			{
@@ -56,6 +78,8 @@ resource "hwmux_device_group" "test" {
 	enable_ahs_actions = true
 	enable_ahs_cas = true
 	permission_groups = ["Staff users"]
+	status = "DISABLED"
+	status_comment = "Terraform acceptance test"
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
@@ -67,6 +91,9 @@ resource "hwmux_device_group" "test" {
 					resource.TestCheckResourceAttr(deviceGroupResourceTfName, "enable_ahs", "true"),
 					resource.TestCheckResourceAttr(deviceGroupResourceTfName, "enable_ahs_actions", "true"),
 					resource.TestCheckResourceAttr(deviceGroupResourceTfName, "enable_ahs_cas", "true"),
+					resource.TestCheckResourceAttr(deviceGroupResourceTfName, "status", "DISABLED"),
+					resource.TestCheckResourceAttr(deviceGroupResourceTfName, "status_comment", "Terraform acceptance test"),
+					resource.TestCheckResourceAttr(deviceGroupResourceTfName, "online", "false"),
 					// Verify the device_group item has Computed attributes filled.
 					resource.TestCheckResourceAttr(deviceGroupResourceTfName, "metadata", "{}"),
 					resource.TestCheckResourceAttr(deviceGroupResourceTfName, "source", "TERRAFORM"),
@@ -76,3 +103,101 @@ resource "hwmux_device_group" "test" {
 		},
 	})
 }
+
+func TestDeviceGroupUpdateKeepsUnmanagedMetadata(t *testing.T) {
+	ctx := context.Background()
+	var sentMetadata map[string]interface{}
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/api/groups/1/":
+			w.Write([]byte(`{"id": 1, "name": "pool", "metadata": {"owner": "old", "ahs_status": "healthy"}}`))
+		case req.Method == http.MethodPut && req.URL.Path == "/api/groups/1/":
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			sentMetadata, _ = body["metadata"].(map[string]interface{})
+			body["id"] = 1
+			json.NewEncoder(w).Encode(body)
+		default:
+			w.Write([]byte(`{}`))
+		}
+	})
+
+	r := NewDeviceGroupResource()
+	r.(tfresource.ResourceWithConfigure).Configure(ctx, tfresource.ConfigureRequest{ProviderData: client}, &tfresource.ConfigureResponse{})
+
+	schemaResp := &tfresource.SchemaResponse{}
+	r.Schema(ctx, tfresource.SchemaRequest{}, schemaResp)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	timeoutsType := schemaResp.Schema.Blocks["timeouts"].Type().(timeouts.Type)
+	diags := plan.Set(ctx, &DeviceGroupResourceModel{
+		ID:                  types.StringValue("1"),
+		Name:                types.StringValue("pool"),
+		Metadata:            types.StringValue(`{"owner": "team_a"}`),
+		MetadataObject:      types.MapUnknown(types.StringType),
+		ManagedMetadataKeys: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("owner")}),
+		Devices:             []types.Int64{},
+		PermissionGroups:    []types.String{},
+		Enable_ahs:          types.BoolUnknown(),
+		Enable_ahs_actions:  types.BoolUnknown(),
+		Enable_ahs_cas:      types.BoolUnknown(),
+		LastUpdated:         types.StringUnknown(),
+		Source:              types.StringValue("TERRAFORM"),
+		Online:              types.BoolUnknown(),
+		Status:              types.StringUnknown(),
+		StatusComment:       types.StringNull(),
+		Timeouts:            timeouts.Value{Object: types.ObjectNull(timeoutsType.AttrTypes)},
+	})
+	if diags.HasError() {
+		t.Fatalf("unable to build the plan: %v", diags)
+	}
+
+	resp := &tfresource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Update(ctx, tfresource.UpdateRequest{Plan: plan, State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	expected := map[string]interface{}{"owner": "team_a", "ahs_status": "healthy"}
+	if !reflect.DeepEqual(sentMetadata, expected) {
+		t.Errorf("expected %v to be sent to hwmux, got %v", expected, sentMetadata)
+	}
+
+	var metadata string
+	resp.State.GetAttribute(ctx, path.Root("metadata"), &metadata)
+	if metadata != `{"owner": "team_a"}` {
+		t.Errorf("expected the unmanaged keys to be ignored in the state, got %s", metadata)
+	}
+}
+
+func TestDeviceGroupImportDoesNotTrackDevices(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 5, "name": "pool", "devices": [{"id": 1}, {"id": 2}], "metadata": {}}`))
+	})
+
+	r := NewDeviceGroupResource()
+	r.(tfresource.ResourceWithConfigure).Configure(ctx, tfresource.ConfigureRequest{ProviderData: client}, &tfresource.ConfigureResponse{})
+
+	importResp := &tfresource.ImportStateResponse{State: newFakeResourceState(t, r, map[string]string{})}
+	r.(tfresource.ResourceWithImportState).ImportState(ctx, tfresource.ImportStateRequest{ID: "5"}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected import error: %v", importResp.Diagnostics)
+	}
+
+	readResp := &tfresource.ReadResponse{State: importResp.State}
+	r.Read(ctx, tfresource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read error: %v", readResp.Diagnostics)
+	}
+
+	var data DeviceGroupResourceModel
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &data)...)
+	if data.ID.ValueString() != "5" || data.Name.ValueString() != "pool" {
+		t.Errorf("expected device group 5 pool, got %s %s", data.ID, data.Name)
+	}
+	if data.Devices != nil {
+		t.Errorf("expected the devices of the imported device group not to be tracked, got %v", data.Devices)
+	}
+}
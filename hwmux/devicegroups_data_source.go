@@ -0,0 +1,228 @@
+package hwmux
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &DeviceGroupsDataSource{}
+
+func NewDeviceGroupsDataSource() datasource.DataSource {
+	return &DeviceGroupsDataSource{}
+}
+
+type DeviceGroupsDataSource struct {
+	client *hwmux.APIClient
+}
+
+// deviceGroupsDataSourceModel maps the data source schema data.
+type DeviceGroupsDataSourceModel struct {
+	ID              types.String                 `tfsdk:"id"`
+	Name            types.String                 `tfsdk:"name"`
+	Name_regex      types.String                 `tfsdk:"name_regex"`
+	Source          types.String                 `tfsdk:"source"`
+	PermissionGroup types.String                 `tfsdk:"permission_group"`
+	MetadataFilter  map[string]types.String      `tfsdk:"metadata"`
+	Include_perms   types.Bool                   `tfsdk:"include_permissions"`
+	DeviceGroups    []DeviceGroupDataSourceModel `tfsdk:"device_groups"`
+}
+
+func (d *DeviceGroupsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_groups"
+}
+
+func (d *DeviceGroupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	permissionsAttribute := objectPermissionsDataSourceAttribute("device group")
+	permissionsAttribute.MarkdownDescription += " Only set when `include_permissions` is true."
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Device Groups data source. Lists all the device groups matching the given filters.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder identifier. Set to satisfy terraform restrictions.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Only return the device groups with this exact name.",
+				Optional:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only return the device groups whose name matches this regular expression.",
+				Optional:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Only return device groups created from this source.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(sourceEnumValues()...),
+				},
+			},
+			"permission_group": schema.StringAttribute{
+				MarkdownDescription: "Only return the device groups that this permission group has access to.",
+				Optional:            true,
+			},
+			"metadata": schema.MapAttribute{
+				MarkdownDescription: "Only return the device groups whose metadata contains all of these key/value pairs. " +
+					"Values that are not strings in the metadata are compared using their json encoding.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"include_permissions": schema.BoolAttribute{
+				MarkdownDescription: "Also read the object-level permissions of every device group. " +
+					"This sends one more request per device group, so it is disabled by default.",
+				Optional: true,
+			},
+			"device_groups": schema.ListNestedAttribute{
+				MarkdownDescription: "The device groups matching the filters.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "Device Group identifier",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Device Group name. Must be unique.",
+							Computed:            true,
+						},
+						"metadata": schema.StringAttribute{
+							MarkdownDescription: "The metadata of the Device Group.",
+							Computed:            true,
+						},
+						"devices": schema.ListNestedAttribute{
+							MarkdownDescription: "The devices that belong to the Device Group",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.Int64Attribute{
+										MarkdownDescription: "Device ID.",
+										Computed:            true,
+									},
+								},
+							},
+						},
+						"enable_ahs": schema.BoolAttribute{
+							MarkdownDescription: "Enable the Automated Health Service",
+							Computed:            true,
+						},
+						"enable_ahs_actions": schema.BoolAttribute{
+							MarkdownDescription: "Allow the Automated Health Service to take DeviceGroups offline when they are unhealthy.",
+							Computed:            true,
+						},
+						"enable_ahs_cas": schema.BoolAttribute{
+							MarkdownDescription: "Enable the Automated Health Service to take Corrective Actions.",
+							Computed:            true,
+						},
+						"source": schema.StringAttribute{
+							MarkdownDescription: "The source where the device group was created.",
+							Computed:            true,
+						},
+						"permissions": permissionsAttribute,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DeviceGroupsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hwmux.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *hwmux.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DeviceGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DeviceGroupsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, err := regexpFilterFromConfig(data.Name_regex, "name_regex", &resp.Diagnostics)
+	if err != nil {
+		return
+	}
+
+	// the filters supported by the API are applied server side
+	request := d.client.GroupsApi.GroupsList(ctx)
+	if !data.Name.IsNull() {
+		request = request.Name(data.Name.ValueString())
+	}
+	if !data.Source.IsNull() {
+		request = request.Source(data.Source.ValueString())
+	}
+	if !data.PermissionGroup.IsNull() {
+		request = request.IncludePermissionGroups(true)
+	}
+
+	deviceGroups, err := ListDeviceGroups(&resp.Diagnostics, request)
+	if err != nil {
+		return
+	}
+
+	metadataFilter := metadataFilterFromConfig(data.MetadataFilter)
+
+	// the remaining filters are applied client side
+	data.DeviceGroups = []DeviceGroupDataSourceModel{}
+	for i := range deviceGroups {
+		deviceGroup := &deviceGroups[i]
+		if nameRegex != nil && !nameRegex.MatchString(deviceGroup.GetName()) {
+			continue
+		}
+		if !data.PermissionGroup.IsNull() && !containsString(deviceGroup.GetPermissionGroups(), data.PermissionGroup.ValueString()) {
+			continue
+		}
+		if !MetadataMatches(deviceGroup.GetMetadata(), metadataFilter) {
+			continue
+		}
+
+		var deviceGroupModel DeviceGroupDataSourceModel
+		err = updateDeviceGroupDataSourceModelFromResponse(deviceGroup, &deviceGroupModel, &resp.Diagnostics)
+		if err != nil {
+			return
+		}
+		if data.Include_perms.ValueBool() {
+			objectPerms, _, err := GetObjectPermissions(ctx, d.client, &resp.Diagnostics, "device_group", deviceGroup.GetId())
+			if err != nil {
+				return
+			}
+			deviceGroupModel.Permissions, err = objectPermissionsValue(ctx, objectPerms, &resp.Diagnostics)
+			if err != nil {
+				return
+			}
+		}
+		data.DeviceGroups = append(data.DeviceGroups, deviceGroupModel)
+	}
+
+	data.ID = types.StringValue("device_groups")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
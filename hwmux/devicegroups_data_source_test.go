@@ -0,0 +1,94 @@
+package hwmux
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDeviceGroupsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "hwmux_device_groups" "test" {
+	permission_group = "All users"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.hwmux_device_groups.test", "device_groups.#"),
+					resource.TestCheckResourceAttrSet("data.hwmux_device_groups.test", "device_groups.0.id"),
+					resource.TestCheckResourceAttrSet("data.hwmux_device_groups.test", "device_groups.0.name"),
+					resource.TestCheckResourceAttrSet("data.hwmux_device_groups.test", "device_groups.0.metadata"),
+					resource.TestCheckNoResourceAttr("data.hwmux_device_groups.test", "device_groups.0.permissions"),
+				),
+			},
+			// Client side filtering
+			{
+				Config: providerConfig + `
+data "hwmux_device_groups" "test" {
+	name_regex          = "^group0$"
+	include_permissions = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hwmux_device_groups.test", "device_groups.#", "1"),
+					resource.TestCheckResourceAttr("data.hwmux_device_groups.test", "device_groups.0.name", "group0"),
+					resource.TestCheckResourceAttrSet("data.hwmux_device_groups.test", "device_groups.0.permissions.user_groups.%"),
+				),
+			},
+		},
+	})
+}
+
+func TestDeviceGroupsDataSourceFilters(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("include_permission_groups") != "true" {
+			t.Errorf("expected the permission groups to be requested from hwmux, got %q", req.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if req.URL.Query().Get("page") == "1" {
+			w.Write([]byte(`{"count": 3, "next": "http://hwmux/api/groups/?page=2", "results": [
+				{"id": 1, "name": "ci_a", "devices": [{"id": 4}], "permission_groups": ["Team"], "metadata": {"pool": "ci"}},
+				{"id": 2, "name": "ci_b", "devices": [], "permission_groups": ["Other"], "metadata": {"pool": "ci"}}
+			]}`))
+			return
+		}
+		w.Write([]byte(`{"count": 3, "next": null, "results": [
+			{"id": 3, "name": "ci_c", "devices": [], "permission_groups": ["Team"], "metadata": {"pool": "dev"}}
+		]}`))
+	})
+
+	d := NewDeviceGroupsDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	config := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	config.SetAttribute(ctx, path.Root("permission_group"), "Team")
+	config.SetAttribute(ctx, path.Root("metadata"), map[string]string{"pool": "ci"})
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var data DeviceGroupsDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if len(data.DeviceGroups) != 1 || data.DeviceGroups[0].Name.ValueString() != "ci_a" {
+		t.Fatalf("expected only ci_a to match the filters, got %v", data.DeviceGroups)
+	}
+	if len(data.DeviceGroups[0].Devices) != 1 || data.DeviceGroups[0].Devices[0].ID.ValueInt64() != 4 {
+		t.Fatalf("expected the devices of ci_a to be set, got %v", data.DeviceGroups[0].Devices)
+	}
+}
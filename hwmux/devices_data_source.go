@@ -0,0 +1,253 @@
+package hwmux
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &DevicesDataSource{}
+
+func NewDevicesDataSource() datasource.DataSource {
+	return &DevicesDataSource{}
+}
+
+type DevicesDataSource struct {
+	client *hwmux.APIClient
+}
+
+// devicesDataSourceModel maps the data source schema data.
+type DevicesDataSourceModel struct {
+	ID               types.String            `tfsdk:"id"`
+	Part             types.String            `tfsdk:"part"`
+	Room             types.String            `tfsdk:"room"`
+	Online           types.Bool              `tfsdk:"online"`
+	Is_wstk          types.Bool              `tfsdk:"is_wstk"`
+	Source           types.String            `tfsdk:"source"`
+	Sn_or_name       types.String            `tfsdk:"sn_or_name"`
+	Sn_or_name_regex types.String            `tfsdk:"sn_or_name_regex"`
+	MetadataFilter   map[string]types.String `tfsdk:"metadata"`
+	Include_perms    types.Bool              `tfsdk:"include_permissions"`
+	Devices          []DeviceDataSourceModel `tfsdk:"devices"`
+}
+
+func (d *DevicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_devices"
+}
+
+func (d *DevicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	permissionsAttribute := objectPermissionsDataSourceAttribute("device")
+	permissionsAttribute.MarkdownDescription += " Only set when `include_permissions` is true."
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Devices data source. Lists all the devices matching the given filters.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder identifier. Set to satisfy terraform restrictions.",
+				Computed:            true,
+			},
+			"part": schema.StringAttribute{
+				MarkdownDescription: "Only return devices with this part number.",
+				Optional:            true,
+			},
+			"room": schema.StringAttribute{
+				MarkdownDescription: "Only return devices located in this room.",
+				Optional:            true,
+			},
+			"online": schema.BoolAttribute{
+				MarkdownDescription: "Only return devices that are online (true) or offline (false).",
+				Optional:            true,
+			},
+			"is_wstk": schema.BoolAttribute{
+				MarkdownDescription: "Only return devices that are (true) or are not (false) WSTKs.",
+				Optional:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Only return devices created from this source.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(sourceEnumValues()...),
+				},
+			},
+			"sn_or_name": schema.StringAttribute{
+				MarkdownDescription: "Only return the devices with this exact name.",
+				Optional:            true,
+			},
+			"sn_or_name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only return the devices whose name matches this regular expression.",
+				Optional:            true,
+			},
+			"metadata": schema.MapAttribute{
+				MarkdownDescription: "Only return the devices whose metadata contains all of these key/value pairs. " +
+					"Values that are not strings in the metadata are compared using their json encoding.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"include_permissions": schema.BoolAttribute{
+				MarkdownDescription: "Also read the object-level permissions of every device. " +
+					"This sends one more request per device, so it is disabled by default.",
+				Optional: true,
+			},
+			"devices": schema.ListNestedAttribute{
+				MarkdownDescription: "The devices matching the filters.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "Device identifier",
+							Computed:            true,
+						},
+						"sn_or_name": schema.StringAttribute{
+							MarkdownDescription: "Device name. Must be unique.",
+							Computed:            true,
+						},
+						"uri": schema.StringAttribute{
+							MarkdownDescription: "The URI or IP address of the device.",
+							Computed:            true,
+						},
+						"part": schema.StringAttribute{
+							MarkdownDescription: "The part number of the device.",
+							Computed:            true,
+						},
+						"is_wstk": schema.BoolAttribute{
+							MarkdownDescription: "If the device is a WSTK.",
+							Computed:            true,
+						},
+						"wstk_part": schema.StringAttribute{
+							MarkdownDescription: "The part number of the wstk the device is on.",
+							Computed:            true,
+						},
+						"online": schema.BoolAttribute{
+							MarkdownDescription: "If the device is online.",
+							Computed:            true,
+						},
+						"metadata": schema.StringAttribute{
+							MarkdownDescription: "The metadata of the device.",
+							Computed:            true,
+						},
+						"source": schema.StringAttribute{
+							Description: "The source where the device was created.",
+							Computed:    true,
+						},
+						"socketed_chip": schema.StringAttribute{
+							Description: "The socket chip detail of the device.",
+							Computed:    true,
+						},
+						"permissions": permissionsAttribute,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DevicesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hwmux.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *hwmux.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DevicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DevicesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snOrNameRegex, err := regexpFilterFromConfig(data.Sn_or_name_regex, "sn_or_name_regex", &resp.Diagnostics)
+	if err != nil {
+		return
+	}
+
+	// the filters supported by the API are applied server side
+	request := d.client.DevicesApi.DevicesList(ctx)
+	if !data.Part.IsNull() {
+		request = request.Part(data.Part.ValueString())
+	}
+	if !data.Room.IsNull() {
+		request = request.Room(data.Room.ValueString())
+	}
+	if !data.Online.IsNull() {
+		request = request.Online(data.Online.ValueBool())
+	}
+	if !data.Is_wstk.IsNull() {
+		request = request.IsWstk(data.Is_wstk.ValueBool())
+	}
+	if !data.Source.IsNull() {
+		request = request.Source(data.Source.ValueString())
+	}
+	if !data.Sn_or_name.IsNull() {
+		request = request.SnOrName(data.Sn_or_name.ValueString())
+	}
+
+	devices, err := ListDevices(&resp.Diagnostics, request)
+	if err != nil {
+		return
+	}
+
+	metadataFilter := metadataFilterFromConfig(data.MetadataFilter)
+
+	// the remaining filters are applied client side
+	data.Devices = []DeviceDataSourceModel{}
+	for i := range devices {
+		device := &devices[i]
+		// the API filter may match more than the exact name
+		if !data.Sn_or_name.IsNull() && device.GetSnOrName() != data.Sn_or_name.ValueString() {
+			continue
+		}
+		if snOrNameRegex != nil && !snOrNameRegex.MatchString(device.GetSnOrName()) {
+			continue
+		}
+		if !MetadataMatches(device.GetMetadata(), metadataFilter) {
+			continue
+		}
+
+		var deviceModel DeviceDataSourceModel
+		err = updateDeviceDataSourceModelFromResponse(device, &deviceModel, &resp.Diagnostics)
+		if err != nil {
+			return
+		}
+		if data.Include_perms.ValueBool() {
+			objectPerms, _, err := GetObjectPermissions(ctx, d.client, &resp.Diagnostics, "device", device.GetId())
+			if err != nil {
+				return
+			}
+			deviceModel.Permissions, err = objectPermissionsValue(ctx, objectPerms, &resp.Diagnostics)
+			if err != nil {
+				return
+			}
+		}
+		data.Devices = append(data.Devices, deviceModel)
+	}
+
+	data.ID = types.StringValue("devices")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,86 @@
+package hwmux
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDevicesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "hwmux_devices" "test" {
+	part   = "Part_no_0"
+	room   = "Room_0"
+	online = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.hwmux_devices.test", "devices.#"),
+					resource.TestCheckResourceAttr("data.hwmux_devices.test", "devices.0.part", "Part_no_0"),
+					resource.TestCheckResourceAttr("data.hwmux_devices.test", "devices.0.online", "true"),
+					resource.TestCheckResourceAttrSet("data.hwmux_devices.test", "devices.0.id"),
+					resource.TestCheckResourceAttrSet("data.hwmux_devices.test", "devices.0.metadata"),
+				),
+			},
+			// Client side filtering
+			{
+				Config: providerConfig + `
+data "hwmux_devices" "test" {
+	sn_or_name_regex = "^sn0$"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hwmux_devices.test", "devices.#", "1"),
+					resource.TestCheckResourceAttr("data.hwmux_devices.test", "devices.0.sn_or_name", "sn0"),
+					resource.TestCheckResourceAttr("data.hwmux_devices.test", "devices.0.id", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestDevicesDataSourceExactSnOrName(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("sn_or_name") != "sn1" {
+			t.Errorf("expected the sn_or_name filter to be sent to hwmux, got %q", req.URL.RawQuery)
+		}
+		// the hwmux filter also returns the devices whose name contains the value
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"count": 2, "next": null, "results": [
+			{"id": 1, "sn_or_name": "sn1", "part": {"part_no": "Part_no_0"}},
+			{"id": 2, "sn_or_name": "sn10", "part": {"part_no": "Part_no_0"}}
+		]}`))
+	})
+
+	d := NewDevicesDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	config := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	config.SetAttribute(ctx, path.Root("sn_or_name"), "sn1")
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var data DevicesDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if len(data.Devices) != 1 || data.Devices[0].ID.ValueInt64() != 1 {
+		t.Fatalf("expected only sn1 to match, got %v", data.Devices)
+	}
+}
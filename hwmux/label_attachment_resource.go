@@ -0,0 +1,247 @@
+package hwmux
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &LabelAttachmentResource{}
+var _ resource.ResourceWithImportState = &LabelAttachmentResource{}
+
+func NewLabelAttachmentResource() resource.Resource {
+	return &LabelAttachmentResource{}
+}
+
+// LabelAttachmentResource defines the resource implementation.
+type LabelAttachmentResource struct {
+	client *hwmux.APIClient
+}
+
+// LabelAttachmentResourceModel describes the resource data model.
+type LabelAttachmentResourceModel struct {
+	ID              types.String   `tfsdk:"id"`
+	Label_id        types.Int64    `tfsdk:"label_id"`
+	Device_group_id types.Int64    `tfsdk:"device_group_id"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *LabelAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_label_attachment"
+}
+
+func (r *LabelAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Label attachment resource. Attaches a single device group to a label without managing " +
+			"the other device groups of the label, so that several configurations can attach device groups to the same label. " +
+			"The `device_groups` attribute of the `hwmux_label` must be omitted when its device groups are managed with attachments.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Attachment identifier, formatted as `label_id/device_group_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"label_id": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the label.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"device_group_id": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the device group to attach to the label.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *LabelAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hwmux.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *hwmux.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *LabelAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *LabelAttachmentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "create", defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	labelId := int32(data.Label_id.ValueInt64())
+	groupId := int32(data.Device_group_id.ValueInt64())
+
+	_, err := r.updateLabelDeviceGroups(ctx, &resp.Diagnostics, labelId, func(deviceGroups []int32) []int32 {
+		for _, deviceGroup := range deviceGroups {
+			if deviceGroup == groupId {
+				return deviceGroups
+			}
+		}
+		return append(deviceGroups, groupId)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error attaching device group %d to label %d", groupId, labelId),
+			fmt.Sprintf("Could not attach device group %d to label %d, unexpected error: %s", groupId, labelId, err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%d/%d", labelId, groupId))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LabelAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *LabelAttachmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "read", defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	labelId := int32(data.Label_id.ValueInt64())
+	groupId := int32(data.Device_group_id.ValueInt64())
+
+	// Get refreshed label value from hwmux
+	var readDiagnostics diag.Diagnostics
+	label, httpRes, err := GetLabel(ctx, r.client, &readDiagnostics, labelId)
+	if removeResourceIfNotFound(ctx, httpRes, readDiagnostics, resp, "Label") {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error reading label %d", labelId),
+			fmt.Sprintf("Could not read label %d, unexpected error: %s", labelId, err.Error()),
+		)
+		return
+	}
+
+	// the attachment is gone if the device group was detached from the label
+	for _, deviceGroup := range label.GetDeviceGroups() {
+		if deviceGroup == groupId {
+			data.ID = types.StringValue(fmt.Sprintf("%d/%d", labelId, groupId))
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *LabelAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	updateTimeoutsOnly(req, resp)
+}
+
+func (r *LabelAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *LabelAttachmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "delete", defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	labelId := int32(data.Label_id.ValueInt64())
+	groupId := int32(data.Device_group_id.ValueInt64())
+
+	var deleteDiagnostics diag.Diagnostics
+	httpRes, err := r.updateLabelDeviceGroups(ctx, &deleteDiagnostics, labelId, func(deviceGroups []int32) []int32 {
+		remaining := make([]int32, 0, len(deviceGroups))
+		for _, deviceGroup := range deviceGroups {
+			if deviceGroup != groupId {
+				remaining = append(remaining, deviceGroup)
+			}
+		}
+		return remaining
+	})
+	// nothing to detach if the label was deleted
+	if IsNotFound(httpRes) {
+		return
+	}
+	resp.Diagnostics.Append(deleteDiagnostics...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error detaching device group %d from label %d", groupId, labelId),
+			fmt.Sprintf("Could not detach device group %d from label %d, unexpected error: %s", groupId, labelId, err.Error()),
+		)
+		return
+	}
+}
+
+func (r *LabelAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateByCompositeID(ctx, req, resp, "label_id", "device_group_id")
+}
+
+// Read the device groups of the label, update them with the given function and write them back to hwmux.
+// Returns the response of the failed request on error.
+func (r *LabelAttachmentResource) updateLabelDeviceGroups(ctx context.Context, diagnostics *diag.Diagnostics, labelId int32,
+	update func(deviceGroups []int32) []int32) (*http.Response, error) {
+	unlock := lockObject(fmt.Sprintf("label/%d", labelId))
+	defer unlock()
+
+	label, httpRes, err := GetLabel(ctx, r.client, diagnostics, labelId)
+	if err != nil {
+		return httpRes, err
+	}
+
+	patchedLabel := hwmux.NewPatchedLabelSerializerWithPermissions()
+	patchedLabel.SetDeviceGroups(update(label.GetDeviceGroups()))
+
+	_, httpRes, err = r.client.LabelsApi.LabelsPartialUpdate(ctx, labelId).PatchedLabelSerializerWithPermissions(*patchedLabel).Execute()
+	if err != nil {
+		return httpRes, fmt.Errorf("%s%s", err.Error(), ResponseBodyToString(httpRes))
+	}
+	return httpRes, nil
+}
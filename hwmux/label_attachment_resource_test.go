@@ -0,0 +1,42 @@
+package hwmux
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLabelAttachmentResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "hwmux_label" "test" {
+	name              = "test_label_attachment"
+	permission_groups = ["All users"]
+}
+
+resource "hwmux_label_attachment" "test" {
+	label_id        = hwmux_label.test.id
+	device_group_id = 1
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("hwmux_label_attachment.test", "label_id", "hwmux_label.test", "id"),
+					resource.TestCheckResourceAttr("hwmux_label_attachment.test", "device_group_id", "1"),
+					resource.TestCheckResourceAttrSet("hwmux_label_attachment.test", "id"),
+					resource.TestCheckNoResourceAttr("hwmux_label.test", "device_groups.#"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "hwmux_label_attachment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
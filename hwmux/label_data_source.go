@@ -7,6 +7,7 @@ import (
 	"github.com/Silabs-UTF/hwmux-client-golang/v2"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -28,6 +29,7 @@ type LabelDataSourceModel struct {
 	DeviceGroups []nestedDeviceGroupModel `tfsdk:"device_groups"`
 	Metadata     types.String             `tfsdk:"metadata"`
 	Source       types.String             `tfsdk:"source"`
+	Permissions  types.Object             `tfsdk:"permissions"`
 }
 
 type nestedDeviceGroupModel struct {
@@ -61,6 +63,7 @@ func (d *LabelDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				MarkdownDescription: "The source where the label was created.",
 				Computed:            true,
 			},
+			"permissions": objectPermissionsDataSourceAttribute("label"),
 			"device_groups": schema.ListNestedAttribute{
 				MarkdownDescription: "The Device Groups that belong to the Label",
 				Computed:            true,
@@ -111,34 +114,61 @@ func (d *LabelDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	label, _, err := GetLabel(d.client, &resp.Diagnostics, int32(data.ID.ValueInt64()))
+	label, _, err := GetLabel(ctx, d.client, &resp.Diagnostics, int32(data.ID.ValueInt64()))
 	if err != nil {
 		return
 	}
 
-	// Map response body to model
+	deviceGroupNames := make(map[int32]string, len(label.GetDeviceGroups()))
+	for _, deviceGroup := range label.GetDeviceGroups() {
+		fullDeviceGroup, _, err := GetDeviceGroup(ctx, d.client, &resp.Diagnostics, deviceGroup)
+		if err != nil {
+			return
+		}
+
+		deviceGroupNames[deviceGroup] = fullDeviceGroup.GetName()
+	}
+
+	err = updateLabelDataSourceModelFromResponse(label, deviceGroupNames, &data, &resp.Diagnostics)
+	if err != nil {
+		return
+	}
+
+	objectPerms, _, err := GetObjectPermissions(ctx, d.client, &resp.Diagnostics, "label", label.GetId())
+	if err != nil {
+		return
+	}
+	data.Permissions, err = objectPermissionsValue(ctx, objectPerms, &resp.Diagnostics)
+	if err != nil {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Map response body to the data source model. deviceGroupNames maps the IDs of the device groups of the label to their names.
+func updateLabelDataSourceModelFromResponse(label *hwmux.Label, deviceGroupNames map[int32]string, data *LabelDataSourceModel,
+	diagnostics *diag.Diagnostics) error {
 	data.ID = types.Int64Value(int64(label.GetId()))
 	data.Name = types.StringValue(label.GetName())
 	data.Source = types.StringValue(string(label.GetSource()))
 
-	err = MarshalMetadataSetError(label.GetMetadata(), &resp.Diagnostics, "label", &data.Metadata)
+	err := MarshalMetadataSetError(label.GetMetadata(), diagnostics, "label", &data.Metadata)
 	if err != nil {
-		return
+		return err
 	}
 
 	data.DeviceGroups = make([]nestedDeviceGroupModel, len(label.GetDeviceGroups()))
 	for i, deviceGroup := range label.GetDeviceGroups() {
-		fullDeviceGroup, _, err := GetDeviceGroup(d.client, &resp.Diagnostics, deviceGroup)
-		if err != nil {
-			return
-		}
-
 		data.DeviceGroups[i] = nestedDeviceGroupModel{
 			ID:   types.Int64Value(int64(deviceGroup)),
-			Name: types.StringValue(fullDeviceGroup.GetName()),
+			Name: types.StringValue(deviceGroupNames[deviceGroup]),
 		}
 	}
 
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	// the permissions are read separately, only when they are requested
+	data.Permissions = types.ObjectNull(objectPermissionsAttrTypes)
+
+	return nil
 }
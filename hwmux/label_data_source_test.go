@@ -22,6 +22,8 @@ func TestAccLabelDataSource(t *testing.T) {
 					resource.TestCheckResourceAttrSet("data.hwmux_label.test", "device_groups.0.name"),
 					resource.TestCheckResourceAttrSet("data.hwmux_label.test", "metadata"),
 					resource.TestCheckResourceAttrSet("data.hwmux_label.test", "source"),
+					resource.TestCheckResourceAttrSet("data.hwmux_label.test", "permissions.user_groups.%"),
+					resource.TestCheckResourceAttrSet("data.hwmux_label.test", "permissions.users.%"),
 				),
 			},
 		},
@@ -8,9 +8,9 @@ import (
 	"time"
 
 	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -37,10 +37,12 @@ type LabelResourceModel struct {
 	ID               types.String   `tfsdk:"id"`
 	Name             types.String   `tfsdk:"name"`
 	Metadata         types.String   `tfsdk:"metadata"`
+	MetadataObject   types.Map      `tfsdk:"metadata_object"`
 	DeviceGroups     []types.Int64  `tfsdk:"device_groups"`
 	PermissionGroups []types.String `tfsdk:"permission_groups"`
 	LastUpdated      types.String   `tfsdk:"last_updated"`
 	Source           types.String   `tfsdk:"source"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *LabelResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -73,16 +75,23 @@ func (r *LabelResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:            true,
 				Computed:            true,
 				MarkdownDescription: "Label metadata.",
+				PlanModifiers: []planmodifier.String{
+					metadataSemanticEquality{},
+				},
 			},
+			"metadata_object": metadataObjectAttribute("label"),
 			"permission_groups": schema.SetAttribute{
 				MarkdownDescription: "Which permission groups can access the resource.",
 				ElementType:         types.StringType,
 				Required:            true,
 			},
 			"device_groups": schema.SetAttribute{
-				MarkdownDescription: "The IDs of the deviceGroups that belong to the label.",
-				ElementType:         types.Int64Type,
-				Required:            true,
+				MarkdownDescription: "The IDs of the deviceGroups that belong to the label. When set, the list is authoritative and " +
+					"device groups attached to the label outside of this resource are detached. Omit it to attach device groups with " +
+					"`hwmux_label_attachment` resources instead; the two styles must not be mixed for the same label. " +
+					"Imported labels don't track their device groups, the first apply that sets `device_groups` replaces them.",
+				ElementType: types.Int64Type,
+				Optional:    true,
 			},
 			"last_updated": schema.StringAttribute{
 				Description: "Timestamp of the last Terraform update of the resource.",
@@ -93,6 +102,9 @@ func (r *LabelResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Computed:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -126,6 +138,9 @@ func (r *LabelResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "create", defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	labelSerializer, err := createLabelFromPlan(data, &resp.Diagnostics)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -134,20 +149,25 @@ func (r *LabelResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	// device_groups is always sent on creation, a new label has no device groups when it is unset
+	if data.DeviceGroups == nil {
+		labelSerializer.SetDeviceGroups([]int32{})
+	}
+
 	// create new label
-	labelSerializer, httpRes, err := r.client.LabelsApi.LabelsCreate(context.Background()).LabelSerializerWithPermissions(*labelSerializer).Execute()
+	labelSerializer, httpRes, err := r.client.LabelsApi.LabelsCreate(ctx).LabelSerializerWithPermissions(*labelSerializer).Execute()
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Error creating label %s", data.Name.String()),
-			fmt.Sprintf("Could not create label %s, unexpected error: %s\n%s", data.Name.String(), err.Error(), BodyToString(&httpRes.Body)),
+			fmt.Sprintf("Could not create label %s, unexpected error: %s\n%s", data.Name.String(), err.Error(), ResponseBodyToString(httpRes)),
 		)
 		return
 	}
 
 	// Map response body to schema and populate Computed attribute values
 	// set model based on response
-	err = updateLabelModelFromResponse(labelSerializer, data, &resp.Diagnostics, r.client)
+	err = updateLabelModelFromResponse(ctx, labelSerializer, data, &resp.Diagnostics, r.client)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Updating the label model failed", err.Error(),
@@ -169,9 +189,16 @@ func (r *LabelResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "read", defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	// Get refreshed label value from hwmux
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	label, _, err := GetLabel(r.client, &resp.Diagnostics, int32(id))
+	var readDiagnostics diag.Diagnostics
+	label, httpRes, err := GetLabel(ctx, r.client, &readDiagnostics, int32(id))
+	if removeResourceIfNotFound(ctx, httpRes, readDiagnostics, resp, "Label") {
+		return
+	}
 	if err != nil {
 		// add diagnostic message with the expected ID
 		resp.Diagnostics.AddError(
@@ -191,9 +218,17 @@ func (r *LabelResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	data.DeviceGroups = make([]types.Int64, len(label.GetDeviceGroups()))
-	for i, deviceGroup := range label.GetDeviceGroups() {
-		data.DeviceGroups[i] = types.Int64Value(int64(deviceGroup))
+	err = MetadataObjectSetError(label.GetMetadata(), &resp.Diagnostics, "label", &data.MetadataObject)
+	if err != nil {
+		return
+	}
+
+	// the device groups are only tracked when they are managed by this resource
+	if data.DeviceGroups != nil {
+		data.DeviceGroups = make([]types.Int64, len(label.GetDeviceGroups()))
+		for i, deviceGroup := range label.GetDeviceGroups() {
+			data.DeviceGroups[i] = types.Int64Value(int64(deviceGroup))
+		}
 	}
 
 	permissionGroups := label.GetPermissionGroups()
@@ -216,7 +251,10 @@ func (r *LabelResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	labelSerializer, err := createLabelFromPlan(data, &resp.Diagnostics)
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "update", defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	patchedLabel, err := patchLabelFromPlan(data, &resp.Diagnostics)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to create label API request based on plan", err.Error(),
@@ -224,24 +262,20 @@ func (r *LabelResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	if data.Source.ValueString() != "TERRAFORM" {
-		labelSerializer.SetSource(hwmux.SOURCEENUM_TERRAFORM)
-	}
-
-	// update label
+	// update label, the fields that are not sent are left untouched
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	labelSerializer, httpRes, err := r.client.LabelsApi.LabelsUpdate(context.Background(), int32(id)).LabelSerializerWithPermissions(*labelSerializer).Execute()
+	labelSerializer, httpRes, err := r.client.LabelsApi.LabelsPartialUpdate(ctx, int32(id)).PatchedLabelSerializerWithPermissions(*patchedLabel).Execute()
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating label "+data.ID.String(),
-			fmt.Sprintf("Could not update label %d, unexpected error: %s\n%s", id, err.Error(), BodyToString(&httpRes.Body)),
+			fmt.Sprintf("Could not update label %d, unexpected error: %s\n%s", id, err.Error(), ResponseBodyToString(httpRes)),
 		)
 		return
 	}
 
 	// set model based on response
-	err = updateLabelModelFromResponse(labelSerializer, data, &resp.Diagnostics, r.client)
+	err = updateLabelModelFromResponse(ctx, labelSerializer, data, &resp.Diagnostics, r.client)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Updating the label model failed", err.Error(),
@@ -263,20 +297,27 @@ func (r *LabelResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "delete", defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	// Delete existing
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	httpRes, err := r.client.LabelsApi.LabelsDestroy(context.Background(), int32(id)).Execute()
+	httpRes, err := r.client.LabelsApi.LabelsDestroy(ctx, int32(id)).Execute()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Error deleting label %d", id),
-			fmt.Sprintf("Could not delete label %d, unexpected error: %s\n%s", id, err.Error(), BodyToString(&httpRes.Body)),
+			fmt.Sprintf("Could not delete label %d, unexpected error: %s\n%s", id, err.Error(), ResponseBodyToString(httpRes)),
 		)
 		return
 	}
 }
 
 func (r *LabelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// device_groups is left unset, so imported labels don't track their device groups until the configuration sets them
+	importStateByIDOrName(ctx, req, resp, "name", func(name string) (int32, error) {
+		label, err := GetLabelByName(ctx, r.client, &resp.Diagnostics, name)
+		return label.GetId(), err
+	})
 }
 
 // Create a Label based on a terraform plan
@@ -285,20 +326,22 @@ func createLabelFromPlan(plan *LabelResourceModel, diagnostics *diag.Diagnostics
 	labelSerializer.SetName(plan.Name.ValueString())
 	labelSerializer.SetSource(hwmux.SOURCEENUM_TERRAFORM)
 
-	if !plan.Metadata.IsUnknown() {
-		metadata, errorMet := UnmarshalMetadataSetError(plan.Metadata.ValueString(), diagnostics, "label")
-		if errorMet != nil {
-			return nil, errorMet
-		}
+	metadata, errorMet := metadataFromPlan(plan.Metadata, plan.MetadataObject, diagnostics, "label")
+	if errorMet != nil {
+		return nil, errorMet
+	}
+	if metadata != nil {
 		labelSerializer.SetMetadata(*metadata)
 	}
 
-	deviceGroupIds := make([]int32, len(plan.DeviceGroups))
-	for i, device := range plan.DeviceGroups {
-		deviceGroupIds[i] = int32(device.ValueInt64())
-	}
+	if plan.DeviceGroups != nil {
+		deviceGroupIds := make([]int32, len(plan.DeviceGroups))
+		for i, device := range plan.DeviceGroups {
+			deviceGroupIds[i] = int32(device.ValueInt64())
+		}
 
-	labelSerializer.SetDeviceGroups(deviceGroupIds)
+		labelSerializer.SetDeviceGroups(deviceGroupIds)
+	}
 
 	permissionList := make([]string, len(plan.PermissionGroups))
 	for i, permissionGroup := range plan.PermissionGroups {
@@ -310,8 +353,30 @@ func createLabelFromPlan(plan *LabelResourceModel, diagnostics *diag.Diagnostics
 	return labelSerializer, nil
 }
 
+// Create a partial Label update based on a terraform plan. The device groups are only sent when device_groups is set,
+// so that the device groups attached with hwmux_label_attachment are kept.
+func patchLabelFromPlan(plan *LabelResourceModel, diagnostics *diag.Diagnostics) (*hwmux.PatchedLabelSerializerWithPermissions, error) {
+	labelSerializer, err := createLabelFromPlan(plan, diagnostics)
+	if err != nil {
+		return nil, err
+	}
+
+	patchedLabel := hwmux.NewPatchedLabelSerializerWithPermissions()
+	patchedLabel.SetName(labelSerializer.GetName())
+	patchedLabel.SetSource(labelSerializer.GetSource())
+	patchedLabel.SetPermissionGroups(labelSerializer.GetPermissionGroups())
+	if labelSerializer.Metadata != nil {
+		patchedLabel.SetMetadata(labelSerializer.GetMetadata())
+	}
+	if plan.DeviceGroups != nil {
+		patchedLabel.SetDeviceGroups(labelSerializer.GetDeviceGroups())
+	}
+
+	return patchedLabel, nil
+}
+
 // Map response body to model and populate Computed attribute values
-func updateLabelModelFromResponse(label *hwmux.LabelSerializerWithPermissions, plan *LabelResourceModel, diagnostics *diag.Diagnostics, client *hwmux.APIClient) (err error) {
+func updateLabelModelFromResponse(ctx context.Context, label *hwmux.LabelSerializerWithPermissions, plan *LabelResourceModel, diagnostics *diag.Diagnostics, client *hwmux.APIClient) (err error) {
 	// Map response body to schema and populate Computed attribute values
 	plan.ID = types.StringValue(strconv.Itoa(int(label.GetId())))
 	plan.Name = types.StringValue(label.GetName())
@@ -322,12 +387,19 @@ func updateLabelModelFromResponse(label *hwmux.LabelSerializerWithPermissions, p
 		return
 	}
 
-	plan.DeviceGroups = make([]types.Int64, len(label.GetDeviceGroups()))
-	for i, deviceGroup := range label.GetDeviceGroups() {
-		plan.DeviceGroups[i] = types.Int64Value(int64(deviceGroup))
+	err = MetadataObjectSetError(label.GetMetadata(), diagnostics, "label", &plan.MetadataObject)
+	if err != nil {
+		return
+	}
+
+	if plan.DeviceGroups != nil {
+		plan.DeviceGroups = make([]types.Int64, len(label.GetDeviceGroups()))
+		for i, deviceGroup := range label.GetDeviceGroups() {
+			plan.DeviceGroups[i] = types.Int64Value(int64(deviceGroup))
+		}
 	}
 
-	permissionGroups, err := GetPermissionGroupsForLabel(client, diagnostics, label.GetId())
+	permissionGroups, err := GetPermissionGroupsForLabel(ctx, client, diagnostics, label.GetId())
 	if err != nil {
 		return
 	}
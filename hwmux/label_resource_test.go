@@ -1,8 +1,16 @@
 package hwmux
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -40,7 +48,15 @@ resource "hwmux_label" "test" {
 				ImportStateVerify: true,
 				// The last_updated attribute does not exist in the HashiCups
 				// API, therefore there is no value for it during import.
-				ImportStateVerifyIgnore: []string{"last_updated"},
+				ImportStateVerifyIgnore: []string{"last_updated", "device_groups"},
+			},
+			// ImportState by name testing
+			{
+				ResourceName:            "hwmux_label.test",
+				ImportState:             true,
+				ImportStateId:           "name:test_label_tf",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "device_groups"},
 			},
 			// Update and Read testing
 			{
@@ -61,7 +77,145 @@ resource "hwmux_label" "test" {
 					resource.TestCheckResourceAttr("hwmux_label.test", "source", "TERRAFORM"),
 				),
 			},
+			// Update with metadata_object and Read testing
+			{
+				Config: providerConfig + `
+resource "hwmux_label" "test" {
+    name     = "test_label_tf"
+    device_groups = [1]
+    permission_groups = ["Staff users"]
+    metadata_object = {
+        owner    = jsonencode("team_a")
+        channels = jsonencode(4)
+        revision = jsonencode("123")
+    }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hwmux_label.test", "metadata_object.%", "3"),
+					resource.TestCheckResourceAttr("hwmux_label.test", "metadata_object.owner", `"team_a"`),
+					resource.TestCheckResourceAttr("hwmux_label.test", "metadata_object.channels", "4"),
+					resource.TestCheckResourceAttr("hwmux_label.test", "metadata_object.revision", `"123"`),
+					resource.TestCheckResourceAttr("hwmux_label.test", "metadata", `{"channels":4,"owner":"team_a","revision":"123"}`),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestLabelDoesNotSendNullDeviceGroups(t *testing.T) {
+	ctx := context.Background()
+	sentBodies := map[string]map[string]interface{}{}
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case req.Method == http.MethodPost && req.URL.Path == "/api/labels/",
+			req.Method == http.MethodPatch && req.URL.Path == "/api/labels/1/":
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			sentBodies[req.Method] = body
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "name": body["name"], "device_groups": []int{3}, "metadata": map[string]interface{}{}})
+		default:
+			w.Write([]byte(`{}`))
+		}
+	})
+
+	r := NewLabelResource()
+	r.(tfresource.ResourceWithConfigure).Configure(ctx, tfresource.ConfigureRequest{ProviderData: client}, &tfresource.ConfigureResponse{})
+
+	schemaResp := &tfresource.SchemaResponse{}
+	r.Schema(ctx, tfresource.SchemaRequest{}, schemaResp)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	timeoutsType := schemaResp.Schema.Blocks["timeouts"].Type().(timeouts.Type)
+	diags := plan.Set(ctx, &LabelResourceModel{
+		ID:               types.StringUnknown(),
+		Name:             types.StringValue("attached"),
+		Metadata:         types.StringUnknown(),
+		MetadataObject:   types.MapUnknown(types.StringType),
+		PermissionGroups: []types.String{},
+		LastUpdated:      types.StringUnknown(),
+		Source:           types.StringUnknown(),
+		Timeouts:         timeouts.Value{Object: types.ObjectNull(timeoutsType.AttrTypes)},
+	})
+	if diags.HasError() {
+		t.Fatalf("unable to build the plan: %v", diags)
+	}
+
+	createResp := &tfresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, tfresource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create error: %v", createResp.Diagnostics)
+	}
+	if deviceGroups, ok := sentBodies[http.MethodPost]["device_groups"].([]interface{}); !ok || len(deviceGroups) != 0 {
+		t.Errorf("expected an empty device_groups list to be sent on creation, got %v", sentBodies[http.MethodPost]["device_groups"])
+	}
+
+	diags = plan.Set(ctx, &LabelResourceModel{
+		ID:               types.StringValue("1"),
+		Name:             types.StringValue("renamed"),
+		Metadata:         types.StringUnknown(),
+		MetadataObject:   types.MapUnknown(types.StringType),
+		PermissionGroups: []types.String{},
+		LastUpdated:      types.StringUnknown(),
+		Source:           types.StringValue("TERRAFORM"),
+		Timeouts:         timeouts.Value{Object: types.ObjectNull(timeoutsType.AttrTypes)},
+	})
+	if diags.HasError() {
+		t.Fatalf("unable to build the plan: %v", diags)
+	}
+
+	updateResp := &tfresource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, tfresource.UpdateRequest{Plan: plan, State: createResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected update error: %v", updateResp.Diagnostics)
+	}
+	if _, ok := sentBodies[http.MethodPatch]["device_groups"]; ok {
+		t.Errorf("expected device_groups not to be sent on update, got %v", sentBodies[http.MethodPatch]["device_groups"])
+	}
+	if sentBodies[http.MethodPatch]["name"] != "renamed" {
+		t.Errorf("expected the new name to be sent on update, got %v", sentBodies[http.MethodPatch]["name"])
+	}
+
+	var data LabelResourceModel
+	updateResp.State.Get(ctx, &data)
+	if data.DeviceGroups != nil {
+		t.Errorf("expected the attached device groups not to be tracked, got %v", data.DeviceGroups)
+	}
+}
+
+func TestLabelImportDoesNotTrackDeviceGroups(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if req.URL.Path == "/api/labels/5/" {
+			w.Write([]byte(`{"id": 5, "name": "nightly", "device_groups": [1, 2], "metadata": {}}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+
+	r := NewLabelResource()
+	r.(tfresource.ResourceWithConfigure).Configure(ctx, tfresource.ConfigureRequest{ProviderData: client}, &tfresource.ConfigureResponse{})
+
+	importResp := &tfresource.ImportStateResponse{State: newFakeResourceState(t, r, map[string]string{})}
+	r.(tfresource.ResourceWithImportState).ImportState(ctx, tfresource.ImportStateRequest{ID: "5"}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected import error: %v", importResp.Diagnostics)
+	}
+
+	readResp := &tfresource.ReadResponse{State: importResp.State}
+	r.Read(ctx, tfresource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read error: %v", readResp.Diagnostics)
+	}
+
+	var data LabelResourceModel
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &data)...)
+	if data.ID.ValueString() != "5" || data.Name.ValueString() != "nightly" {
+		t.Errorf("expected label 5 nightly, got %s %s", data.ID, data.Name)
+	}
+	if data.DeviceGroups != nil {
+		t.Errorf("expected the device groups of the imported label not to be tracked, got %v", data.DeviceGroups)
+	}
+}
@@ -0,0 +1,240 @@
+package hwmux
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &LabelsDataSource{}
+
+func NewLabelsDataSource() datasource.DataSource {
+	return &LabelsDataSource{}
+}
+
+type LabelsDataSource struct {
+	client *hwmux.APIClient
+}
+
+// labelsDataSourceModel maps the data source schema data.
+type LabelsDataSourceModel struct {
+	ID              types.String            `tfsdk:"id"`
+	Name            types.String            `tfsdk:"name"`
+	Name_regex      types.String            `tfsdk:"name_regex"`
+	Source          types.String            `tfsdk:"source"`
+	PermissionGroup types.String            `tfsdk:"permission_group"`
+	MetadataFilter  map[string]types.String `tfsdk:"metadata"`
+	Include_perms   types.Bool              `tfsdk:"include_permissions"`
+	Labels          []LabelDataSourceModel  `tfsdk:"labels"`
+}
+
+func (d *LabelsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_labels"
+}
+
+func (d *LabelsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	permissionsAttribute := objectPermissionsDataSourceAttribute("label")
+	permissionsAttribute.MarkdownDescription += " Only set when `include_permissions` is true."
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Labels data source. Lists all the labels matching the given filters.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder identifier. Set to satisfy terraform restrictions.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Only return the labels with this exact name.",
+				Optional:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only return the labels whose name matches this regular expression.",
+				Optional:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Only return labels created from this source.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(sourceEnumValues()...),
+				},
+			},
+			"permission_group": schema.StringAttribute{
+				MarkdownDescription: "Only return the labels that this permission group has access to.",
+				Optional:            true,
+			},
+			"metadata": schema.MapAttribute{
+				MarkdownDescription: "Only return the labels whose metadata contains all of these key/value pairs. " +
+					"Values that are not strings in the metadata are compared using their json encoding.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"include_permissions": schema.BoolAttribute{
+				MarkdownDescription: "Also read the object-level permissions of every label. " +
+					"This sends one more request per label, so it is disabled by default.",
+				Optional: true,
+			},
+			"labels": schema.ListNestedAttribute{
+				MarkdownDescription: "The labels matching the filters.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "Label identifier",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Label name. Must be unique.",
+							Computed:            true,
+						},
+						"metadata": schema.StringAttribute{
+							MarkdownDescription: "The metadata of the Label.",
+							Computed:            true,
+						},
+						"source": schema.StringAttribute{
+							MarkdownDescription: "The source where the label was created.",
+							Computed:            true,
+						},
+						"permissions": permissionsAttribute,
+						"device_groups": schema.ListNestedAttribute{
+							MarkdownDescription: "The Device Groups that belong to the Label",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.Int64Attribute{
+										MarkdownDescription: "Device Group ID.",
+										Computed:            true,
+									},
+									"name": schema.StringAttribute{
+										MarkdownDescription: "Device Group name.",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *LabelsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hwmux.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *hwmux.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *LabelsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data LabelsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, err := regexpFilterFromConfig(data.Name_regex, "name_regex", &resp.Diagnostics)
+	if err != nil {
+		return
+	}
+
+	// the filters supported by the API are applied server side
+	request := d.client.LabelsApi.LabelsList(ctx)
+	if !data.Name.IsNull() {
+		request = request.Name(data.Name.ValueString())
+	}
+	if !data.Source.IsNull() {
+		request = request.Source(data.Source.ValueString())
+	}
+	if !data.PermissionGroup.IsNull() {
+		request = request.IncludePermissionGroups(true)
+	}
+
+	labels, err := ListLabels(&resp.Diagnostics, request)
+	if err != nil {
+		return
+	}
+
+	metadataFilter := metadataFilterFromConfig(data.MetadataFilter)
+
+	// the remaining filters are applied client side
+	var matchingLabels []*hwmux.Label
+	for i := range labels {
+		label := &labels[i]
+		if nameRegex != nil && !nameRegex.MatchString(label.GetName()) {
+			continue
+		}
+		if !data.PermissionGroup.IsNull() && !containsString(label.GetPermissionGroups(), data.PermissionGroup.ValueString()) {
+			continue
+		}
+		if !MetadataMatches(label.GetMetadata(), metadataFilter) {
+			continue
+		}
+		matchingLabels = append(matchingLabels, label)
+	}
+
+	// the names of the device groups are resolved with a single listing instead of one request per device group
+	hasDeviceGroups := false
+	for _, label := range matchingLabels {
+		hasDeviceGroups = hasDeviceGroups || len(label.GetDeviceGroups()) > 0
+	}
+	deviceGroupNames := map[int32]string{}
+	if hasDeviceGroups {
+		deviceGroups, err := ListDeviceGroups(&resp.Diagnostics, d.client.GroupsApi.GroupsList(ctx))
+		if err != nil {
+			return
+		}
+		for _, deviceGroup := range deviceGroups {
+			deviceGroupNames[deviceGroup.GetId()] = deviceGroup.GetName()
+		}
+	}
+
+	data.Labels = []LabelDataSourceModel{}
+	for _, label := range matchingLabels {
+		var labelModel LabelDataSourceModel
+		err = updateLabelDataSourceModelFromResponse(label, deviceGroupNames, &labelModel, &resp.Diagnostics)
+		if err != nil {
+			return
+		}
+		if data.Include_perms.ValueBool() {
+			objectPerms, _, err := GetObjectPermissions(ctx, d.client, &resp.Diagnostics, "label", label.GetId())
+			if err != nil {
+				return
+			}
+			labelModel.Permissions, err = objectPermissionsValue(ctx, objectPerms, &resp.Diagnostics)
+			if err != nil {
+				return
+			}
+		}
+		data.Labels = append(data.Labels, labelModel)
+	}
+
+	data.ID = types.StringValue("labels")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,102 @@
+package hwmux
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLabelsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "hwmux_labels" "test" {
+	name = "label0"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hwmux_labels.test", "labels.#", "1"),
+					resource.TestCheckResourceAttr("data.hwmux_labels.test", "labels.0.id", "1"),
+					resource.TestCheckResourceAttr("data.hwmux_labels.test", "labels.0.device_groups.#", "8"),
+					resource.TestCheckResourceAttrSet("data.hwmux_labels.test", "labels.0.device_groups.0.name"),
+					resource.TestCheckResourceAttrSet("data.hwmux_labels.test", "labels.0.metadata"),
+				),
+			},
+			// Client side filtering
+			{
+				Config: providerConfig + `
+data "hwmux_labels" "test" {
+	name_regex          = "^label0$"
+	permission_group    = "All users"
+	include_permissions = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hwmux_labels.test", "labels.#", "1"),
+					resource.TestCheckResourceAttr("data.hwmux_labels.test", "labels.0.name", "label0"),
+					resource.TestCheckResourceAttrSet("data.hwmux_labels.test", "labels.0.permissions.user_groups.%"),
+				),
+			},
+		},
+	})
+}
+
+func TestLabelsDataSourceDeviceGroupNames(t *testing.T) {
+	ctx := context.Background()
+	groupListings := 0
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/labels/":
+			w.Write([]byte(`{"count": 2, "next": null, "results": [
+				{"id": 1, "name": "nightly", "device_groups": [3, 5], "metadata": {}},
+				{"id": 2, "name": "weekly", "device_groups": [5], "metadata": {}}
+			]}`))
+		case "/api/groups/":
+			groupListings++
+			w.Write([]byte(`{"count": 2, "next": null, "results": [
+				{"id": 3, "name": "group3", "devices": []},
+				{"id": 5, "name": "group5", "devices": []}
+			]}`))
+		default:
+			t.Errorf("unexpected request to %s", req.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	d := NewLabelsDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	config := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	config.SetAttribute(ctx, path.Root("name_regex"), "ly$")
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var data LabelsDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if len(data.Labels) != 2 {
+		t.Fatalf("expected both labels to match the filters, got %v", data.Labels)
+	}
+	if groupListings != 1 {
+		t.Fatalf("expected the device groups to be listed once, got %d", groupListings)
+	}
+	nightlyGroups := data.Labels[0].DeviceGroups
+	if len(nightlyGroups) != 2 || nightlyGroups[0].Name.ValueString() != "group3" || nightlyGroups[1].Name.ValueString() != "group5" {
+		t.Fatalf("expected the device group names to be resolved, got %v", nightlyGroups)
+	}
+}
@@ -0,0 +1,245 @@
+package hwmux
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const metadataObjectDescription = "Alternative to `metadata` with one entry per metadata key, so that plans show the changes key by key. " +
+	"Each value is the json encoding of the metadata value, so that values keep their type in hwmux: " +
+	"write them with `jsonencode`, such as `jsonencode(\"team_a\")`, `jsonencode(4)` or `jsonencode({ retries = 3 })`, " +
+	"or encode a whole object with `{ for key, value in local.metadata : key => jsonencode(value) }`. Conflicts with `metadata`."
+
+// Schema of the metadata_object attribute shared by the resources with metadata
+func metadataObjectAttribute(resourceName string) schema.MapAttribute {
+	return schema.MapAttribute{
+		MarkdownDescription: fmt.Sprintf("The metadata of the %s, as a map. %s", resourceName, metadataObjectDescription),
+		ElementType:         types.StringType,
+		Optional:            true,
+		Computed:            true,
+		Validators: []validator.Map{
+			mapvalidator.ConflictsWith(path.MatchRoot("metadata")),
+			mapvalidator.ValueStringsAre(metadataValueValidator{}),
+		},
+	}
+}
+
+// metadataSemanticEquality keeps the metadata json of the state when the configured json only differs
+// by its formatting or key order, so that such changes don't plan an update.
+type metadataSemanticEquality struct{}
+
+func (m metadataSemanticEquality) Description(ctx context.Context) string {
+	return "Keeps the prior metadata when the configured json encodes the same value."
+}
+
+func (m metadataSemanticEquality) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m metadataSemanticEquality) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Terraform accepts the prior value in place of a configured value that is equivalent to it
+	if req.StateValue.IsNull() || req.StateValue.IsUnknown() || req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if JSONEqual(req.ConfigValue.ValueString(), req.StateValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// Schema of the managed_metadata_keys attribute shared by the resources supporting partial metadata ownership
+func managedMetadataKeysAttribute(resourceName string) schema.SetAttribute {
+	return schema.SetAttribute{
+		MarkdownDescription: fmt.Sprintf("If set, Terraform only owns these keys of the %s metadata. ", resourceName) +
+			"Updates only write the listed keys and keep the other keys set in hwmux, and the other keys are ignored when refreshing. " +
+			"A listed key that is missing from the configured metadata is removed from hwmux. " +
+			"Keys removed from this list are left in hwmux as they are.",
+		ElementType: types.StringType,
+		Optional:    true,
+	}
+}
+
+// Get the metadata keys managed by Terraform. Returns nil when Terraform manages the whole metadata.
+func managedMetadataKeys(keys types.Set) map[string]bool {
+	if keys.IsNull() || keys.IsUnknown() {
+		return nil
+	}
+	managed := make(map[string]bool, len(keys.Elements()))
+	for _, key := range keys.Elements() {
+		if key, ok := key.(types.String); ok {
+			managed[key.ValueString()] = true
+		}
+	}
+	return managed
+}
+
+// Keep only the metadata keys managed by Terraform
+func filterManagedMetadata(metadata map[string]interface{}, keys types.Set) map[string]interface{} {
+	managed := managedMetadataKeys(keys)
+	if managed == nil {
+		return metadata
+	}
+	filtered := make(map[string]interface{}, len(managed))
+	for key, value := range metadata {
+		if managed[key] {
+			filtered[key] = value
+		}
+	}
+	return filtered
+}
+
+// Sets an error if the planned metadata contains keys that are not managed by Terraform
+func checkManagedMetadataKeys(metadata map[string]interface{}, keys types.Set, diagnostics *diag.Diagnostics, resourceName string) error {
+	managed := managedMetadataKeys(keys)
+	if managed == nil {
+		return nil
+	}
+	var unmanaged []string
+	for key := range metadata {
+		if !managed[key] {
+			unmanaged = append(unmanaged, key)
+		}
+	}
+	if len(unmanaged) == 0 {
+		return nil
+	}
+	sort.Strings(unmanaged)
+	err := fmt.Errorf("the %s metadata keys %q are not listed in managed_metadata_keys", resourceName, unmanaged)
+	diagnostics.AddAttributeError(path.Root("managed_metadata_keys"), "Unmanaged metadata keys", err.Error())
+	return err
+}
+
+// Merge the planned metadata into the current metadata in hwmux. The managed keys are set from the plan,
+// or removed if they are not planned, and the other keys are kept.
+func mergeManagedMetadata(current map[string]interface{}, planned map[string]interface{}, keys types.Set) map[string]interface{} {
+	managed := managedMetadataKeys(keys)
+	if managed == nil {
+		return planned
+	}
+	merged := make(map[string]interface{}, len(current)+len(planned))
+	for key, value := range current {
+		if !managed[key] {
+			merged[key] = value
+		}
+	}
+	for key, value := range planned {
+		merged[key] = value
+	}
+	return merged
+}
+
+// Get the metadata to send to hwmux from the metadata and metadata_object attributes of a plan.
+// Returns nil if neither attribute is known.
+func metadataFromPlan(metadata types.String, metadataObject types.Map, diagnostics *diag.Diagnostics, resourceName string) (
+	*map[string]interface{}, error) {
+	if !metadata.IsUnknown() && !metadata.IsNull() {
+		return UnmarshalMetadataSetError(metadata.ValueString(), diagnostics, resourceName)
+	}
+	if metadataObject.IsUnknown() || metadataObject.IsNull() {
+		return nil, nil
+	}
+
+	result := make(map[string]interface{}, len(metadataObject.Elements()))
+	for key, value := range metadataObject.Elements() {
+		if value, ok := value.(types.String); ok {
+			decoded, err := decodeMetadataValue(value.ValueString())
+			if err != nil {
+				diagnostics.AddAttributeError(path.Root("metadata_object").AtMapKey(key),
+					fmt.Sprintf("Unable to decode %s metadata key %s from json", resourceName, key), err.Error())
+				return nil, err
+			}
+			result[key] = decoded
+		}
+	}
+	return &result, nil
+}
+
+// Set the metadata_object field from the metadata returned by hwmux.
+// Entries of the field that decode to the value in hwmux are kept as they are written in the configuration.
+func MetadataObjectSetError(metadata map[string]interface{}, diagnostics *diag.Diagnostics, resourceName string, field *types.Map) error {
+	prior := map[string]string{}
+	if !field.IsNull() && !field.IsUnknown() {
+		for key, value := range field.Elements() {
+			if value, ok := value.(types.String); ok && !value.IsNull() && !value.IsUnknown() {
+				prior[key] = value.ValueString()
+			}
+		}
+	}
+
+	elements := make(map[string]attr.Value, len(metadata))
+	for key, value := range metadata {
+		if priorValue, ok := prior[key]; ok {
+			if decoded, err := decodeMetadataValue(priorValue); err == nil && reflect.DeepEqual(decoded, value) {
+				elements[key] = types.StringValue(priorValue)
+				continue
+			}
+		}
+		encoded, err := encodeMetadataValue(value)
+		if err != nil {
+			diagnostics.AddError(
+				fmt.Sprintf("Unable to encode %s metadata key %s to json", resourceName, key),
+				err.Error(),
+			)
+			return err
+		}
+		elements[key] = types.StringValue(encoded)
+	}
+
+	mapValue, diags := types.MapValue(types.StringType, elements)
+	diagnostics.Append(diags...)
+	if diags.HasError() {
+		return fmt.Errorf("unable to build the %s metadata_object", resourceName)
+	}
+	*field = mapValue
+	return nil
+}
+
+// Decode a json encoded metadata_object value
+func decodeMetadataValue(value string) (interface{}, error) {
+	var decoded interface{}
+	err := json.Unmarshal([]byte(value), &decoded)
+	return decoded, err
+}
+
+// Encode a metadata value for metadata_object. This is the inverse of decodeMetadataValue.
+func encodeMetadataValue(value interface{}) (string, error) {
+	encoded, err := json.Marshal(value)
+	return string(encoded), err
+}
+
+// metadataValueValidator validates that the metadata_object values are valid json
+type metadataValueValidator struct{}
+
+func (v metadataValueValidator) Description(ctx context.Context) string {
+	return "values must be valid json, such as the result of jsonencode"
+}
+
+func (v metadataValueValidator) MarkdownDescription(ctx context.Context) string {
+	return "values must be valid json, such as the result of `jsonencode`"
+}
+
+func (v metadataValueValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := decodeMetadataValue(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid json metadata value",
+			fmt.Sprintf("%s: %s", v.Description(ctx), err.Error()),
+		)
+	}
+}
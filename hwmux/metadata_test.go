@@ -0,0 +1,204 @@
+package hwmux
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMetadataValueRoundTrip(t *testing.T) {
+	values := []interface{}{
+		"BRD4180",
+		"4",
+		"json:4",
+		float64(4),
+		true,
+		nil,
+		map[string]interface{}{"a": float64(1)},
+		[]interface{}{"a", float64(2)},
+	}
+
+	for _, value := range values {
+		encoded, err := encodeMetadataValue(value)
+		if err != nil {
+			t.Fatalf("unable to encode %#v: %s", value, err)
+		}
+		if decoded, err := decodeMetadataValue(encoded); err != nil || !reflect.DeepEqual(decoded, value) {
+			t.Errorf("%#v was encoded as %q and decoded as %#v (%v)", value, encoded, decoded, err)
+		}
+	}
+}
+
+func TestMetadataFromPlan(t *testing.T) {
+	metadataObject := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"board":    types.StringValue(`"BRD4180"`),
+		"channels": types.StringValue("4"),
+		"count":    types.StringValue(`"123"`),
+		"enabled":  types.StringValue("true"),
+		"empty":    types.StringValue("null"),
+		"config":   types.StringValue(`{"retries": 3}`),
+	})
+
+	var diagnostics diag.Diagnostics
+	metadata, err := metadataFromPlan(types.StringUnknown(), metadataObject, &diagnostics, "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := map[string]interface{}{
+		"board":    "BRD4180",
+		"channels": float64(4),
+		"count":    "123",
+		"enabled":  true,
+		"empty":    nil,
+		"config":   map[string]interface{}{"retries": float64(3)},
+	}
+	if !reflect.DeepEqual(*metadata, expected) {
+		t.Errorf("expected %#v, got %#v", expected, *metadata)
+	}
+
+	metadata, err = metadataFromPlan(types.StringValue(`{"a": 1}`), types.MapUnknown(types.StringType), &diagnostics, "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(*metadata, map[string]interface{}{"a": float64(1)}) {
+		t.Errorf("expected the string metadata to be used, got %#v", *metadata)
+	}
+
+	metadata, err = metadataFromPlan(types.StringUnknown(), types.MapUnknown(types.StringType), &diagnostics, "test")
+	if err != nil || metadata != nil {
+		t.Errorf("expected no metadata when neither attribute is known, got %#v (%v)", metadata, err)
+	}
+
+	invalid := types.MapValueMust(types.StringType, map[string]attr.Value{"board": types.StringValue("BRD4180")})
+	if _, err := metadataFromPlan(types.StringUnknown(), invalid, &diagnostics, "test"); err == nil || !diagnostics.HasError() {
+		t.Errorf("expected an error for a value that is not json")
+	}
+}
+
+func TestMetadataObjectSetErrorKeepsEquivalentValues(t *testing.T) {
+	metadata := map[string]interface{}{"channels": float64(4), "config": map[string]interface{}{"a": float64(1)}, "new": "value"}
+	field := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"channels": types.StringValue("4.0"),
+		"config":   types.StringValue(`{ "a": 1 }`),
+		"removed":  types.StringValue(`"value"`),
+	})
+
+	var diagnostics diag.Diagnostics
+	if err := MetadataObjectSetError(metadata, &diagnostics, "test", &field); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"channels": types.StringValue("4.0"),
+		"config":   types.StringValue(`{ "a": 1 }`),
+		"new":      types.StringValue(`"value"`),
+	})
+	if !field.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, field)
+	}
+}
+
+func TestManagedMetadata(t *testing.T) {
+	keys := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("owner"), types.StringValue("pool")})
+	current := map[string]interface{}{"owner": "old", "pool": "a", "ahs_status": "healthy"}
+
+	filtered := filterManagedMetadata(current, keys)
+	if !reflect.DeepEqual(filtered, map[string]interface{}{"owner": "old", "pool": "a"}) {
+		t.Errorf("unexpected filtered metadata %#v", filtered)
+	}
+	if all := filterManagedMetadata(current, types.SetNull(types.StringType)); !reflect.DeepEqual(all, current) {
+		t.Errorf("expected all keys to be kept without managed keys, got %#v", all)
+	}
+
+	merged := mergeManagedMetadata(current, map[string]interface{}{"owner": "team_a"}, keys)
+	if !reflect.DeepEqual(merged, map[string]interface{}{"owner": "team_a", "ahs_status": "healthy"}) {
+		t.Errorf("unexpected merged metadata %#v", merged)
+	}
+
+	var diagnostics diag.Diagnostics
+	if err := checkManagedMetadataKeys(map[string]interface{}{"owner": "team_a"}, keys, &diagnostics, "test"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := checkManagedMetadataKeys(map[string]interface{}{"ahs_status": "x"}, keys, &diagnostics, "test"); err == nil || !diagnostics.HasError() {
+		t.Errorf("expected an error for an unmanaged key")
+	}
+}
+
+func TestMetadataSemanticEquality(t *testing.T) {
+	testCases := map[string]struct {
+		state    types.String
+		config   types.String
+		expected types.String
+	}{
+		"reformatted": {types.StringValue(`{"a":"x","b":1}`), types.StringValue("{\n  \"b\": 1,\n  \"a\": \"x\"\n}"), types.StringValue(`{"a":"x","b":1}`)},
+		"changed":     {types.StringValue(`{"a":"x","b":1}`), types.StringValue(`{"a":"x","b":2}`), types.StringValue(`{"a":"x","b":2}`)},
+		"create":      {types.StringNull(), types.StringValue(`{"a": "x"}`), types.StringValue(`{"a": "x"}`)},
+		"unknown":     {types.StringValue(`{"a":"x"}`), types.StringUnknown(), types.StringUnknown()},
+		"invalid":     {types.StringValue(`{"a":"x"}`), types.StringValue(`{"a":`), types.StringValue(`{"a":`)},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := planmodifier.StringRequest{StateValue: tc.state, ConfigValue: tc.config, PlanValue: tc.config}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+			metadataSemanticEquality{}.PlanModifyString(context.Background(), req, resp)
+			if !resp.PlanValue.Equal(tc.expected) {
+				t.Errorf("expected %s, got %s", tc.expected, resp.PlanValue)
+			}
+		})
+	}
+}
+
+func TestEncodeMetadataValue(t *testing.T) {
+	testCases := map[string]struct {
+		value    interface{}
+		expected string
+	}{
+		"string":        {"team_a", `"team_a"`},
+		"number string": {"123", `"123"`},
+		"number":        {float64(123), "123"},
+		"bool":          {true, "true"},
+		"null":          {nil, "null"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			encoded, err := encodeMetadataValue(tc.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if encoded != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, encoded)
+			}
+		})
+	}
+}
+
+func TestMetadataValueValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value     types.String
+		expectErr bool
+	}{
+		"string":       {types.StringValue(`"team_a"`), false},
+		"json":         {types.StringValue(`{"a": 1}`), false},
+		"not encoded":  {types.StringValue("team_a"), true},
+		"invalid json": {types.StringValue("{not json"), true},
+		"unknown":      {types.StringUnknown(), false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			metadataValueValidator{}.ValidateString(context.Background(), validator.StringRequest{ConfigValue: tc.value}, resp)
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Errorf("expected error %t, got %v", tc.expectErr, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,348 @@
+package hwmux
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The objects that support object-level permissions
+var objectPermissionTypes = []string{"device", "device_group", "label"}
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ObjectPermissionResource{}
+var _ resource.ResourceWithImportState = &ObjectPermissionResource{}
+
+func NewObjectPermissionResource() resource.Resource {
+	return &ObjectPermissionResource{}
+}
+
+// ObjectPermissionResource defines the resource implementation.
+type ObjectPermissionResource struct {
+	client *hwmux.APIClient
+}
+
+// ObjectPermissionResourceModel describes the resource data model.
+type ObjectPermissionResourceModel struct {
+	ID         types.String   `tfsdk:"id"`
+	ObjectType types.String   `tfsdk:"object_type"`
+	ObjectId   types.Int64    `tfsdk:"object_id"`
+	UserGroup  types.String   `tfsdk:"user_group"`
+	User       types.String   `tfsdk:"user"`
+	Permission types.String   `tfsdk:"permission"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *ObjectPermissionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object_permission"
+}
+
+func (r *ObjectPermissionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Object permission resource. Grants a single permission level on a device, device group or label " +
+			"to a user group or a user. The other permissions of the object are left untouched.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Object permission identifier, formatted as " +
+					"`object_type/object_id/user_group/<name>/permission` or `object_type/object_id/user/<username>/permission`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"object_type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The type of the object. One of `device`, `device_group` or `label`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(objectPermissionTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"object_id": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the object.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"user_group": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The name of the user group to grant the permission to. Exactly one of `user_group` or `user` must be set.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("user_group"), path.MatchRoot("user")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The username of the user to grant the permission to. Exactly one of `user_group` or `user` must be set.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"permission": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: fmt.Sprintf("The permission level to grant, one of %s.", strings.Join(permissionsEnumValues(), ", ")),
+				Validators: []validator.String{
+					stringvalidator.OneOf(permissionsEnumValues()...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *ObjectPermissionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hwmux.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *hwmux.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ObjectPermissionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ObjectPermissionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "create", defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	permission := data.Permission.ValueString()
+	_, err := r.updateObjectPermissions(ctx, &resp.Diagnostics, data, func(permissions []string) []string {
+		for _, granted := range permissions {
+			if granted == permission {
+				return permissions
+			}
+		}
+		return append(permissions, permission)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error granting permission "+data.Permission.String(),
+			fmt.Sprintf("Could not grant permission %s on %s %d, unexpected error: %s",
+				permission, data.ObjectType.ValueString(), data.ObjectId.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(objectPermissionID(data))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ObjectPermissionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ObjectPermissionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "read", defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	// Get refreshed object permissions from hwmux
+	var readDiagnostics diag.Diagnostics
+	objectPerms, httpRes, err := GetObjectPermissions(ctx, r.client, &readDiagnostics, data.ObjectType.ValueString(),
+		int32(data.ObjectId.ValueInt64()))
+	if removeResourceIfNotFound(ctx, httpRes, readDiagnostics, resp, "Object Permission") {
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	// the grant is gone if the permission was revoked
+	grants, principal := objectPermissionGrants(objectPerms, data)
+	for _, permission := range objectPermissionsOf(grants, principal) {
+		if permission == data.Permission.ValueString() {
+			data.ID = types.StringValue(objectPermissionID(data))
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ObjectPermissionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	updateTimeoutsOnly(req, resp)
+}
+
+func (r *ObjectPermissionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ObjectPermissionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "delete", defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	permission := data.Permission.ValueString()
+	var deleteDiagnostics diag.Diagnostics
+	httpRes, err := r.updateObjectPermissions(ctx, &deleteDiagnostics, data, func(permissions []string) []string {
+		remaining := make([]string, 0, len(permissions))
+		for _, granted := range permissions {
+			if granted != permission {
+				remaining = append(remaining, granted)
+			}
+		}
+		return remaining
+	})
+	// nothing to revoke if the object was deleted
+	if IsNotFound(httpRes) {
+		return
+	}
+	resp.Diagnostics.Append(deleteDiagnostics...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error revoking permission "+data.Permission.String(),
+			fmt.Sprintf("Could not revoke permission %s on %s %d, unexpected error: %s",
+				permission, data.ObjectType.ValueString(), data.ObjectId.ValueInt64(), err.Error()),
+		)
+		return
+	}
+}
+
+func (r *ObjectPermissionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// user group names may contain slashes, so the principal is everything between the principal type and the permission
+	parts := strings.Split(req.ID, "/")
+	if len(parts) < 5 || (parts[2] != "user_group" && parts[2] != "user") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: object_type/object_id/user_group/<name>/permission "+
+				"or object_type/object_id/user/<username>/permission. Got: %q", req.ID),
+		)
+		return
+	}
+	objectId, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected a numeric object_id in the import identifier. Got: %q", parts[1]),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("object_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("object_id"), objectId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(parts[2]), strings.Join(parts[3:len(parts)-1], "/"))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("permission"), parts[len(parts)-1])...)
+}
+
+// Read the permissions of the user or user group on the object, update them with the given function
+// and write them back to hwmux. Returns the response of the failed request on error.
+func (r *ObjectPermissionResource) updateObjectPermissions(ctx context.Context, diagnostics *diag.Diagnostics,
+	data *ObjectPermissionResourceModel, update func(permissions []string) []string) (*http.Response, error) {
+	objectType := data.ObjectType.ValueString()
+	id := int32(data.ObjectId.ValueInt64())
+
+	unlock := lockObject(fmt.Sprintf("%s/%d/permissions", objectType, id))
+	defer unlock()
+
+	objectPerms, httpRes, err := GetObjectPermissions(ctx, r.client, diagnostics, objectType, id)
+	if err != nil {
+		return httpRes, err
+	}
+
+	// the patch replaces the whole users or user_groups field, so the other grants are sent back as they are
+	grants, principal := objectPermissionGrants(objectPerms, data)
+	patched := make(map[string]interface{}, len(grants)+1)
+	for key, value := range grants {
+		patched[key] = value
+	}
+	patched[principal] = update(objectPermissionsOf(grants, principal))
+
+	patchedPerms := hwmux.NewPatchedObjectPermissions()
+	if data.User.IsNull() {
+		patchedPerms.SetUserGroups(patched)
+	} else {
+		patchedPerms.SetUsers(patched)
+	}
+
+	switch objectType {
+	case "device":
+		_, httpRes, err = r.client.DevicesApi.DevicesPermissionsPartialUpdate(ctx, id).PatchedObjectPermissions(*patchedPerms).Execute()
+	case "device_group":
+		_, httpRes, err = r.client.GroupsApi.GroupsPermissionsPartialUpdate(ctx, id).PatchedObjectPermissions(*patchedPerms).Execute()
+	case "label":
+		_, httpRes, err = r.client.LabelsApi.LabelsPermissionsPartialUpdate(ctx, id).PatchedObjectPermissions(*patchedPerms).Execute()
+	}
+	if err != nil {
+		return httpRes, fmt.Errorf("%s%s", err.Error(), ResponseBodyToString(httpRes))
+	}
+	return httpRes, nil
+}
+
+// Get the grants of the kind of principal of the resource, and the name of the principal
+func objectPermissionGrants(objectPerms *hwmux.ObjectPermissions, data *ObjectPermissionResourceModel) (map[string]interface{}, string) {
+	if data.User.IsNull() {
+		return objectPerms.GetUserGroups(), data.UserGroup.ValueString()
+	}
+	return objectPerms.GetUsers(), data.User.ValueString()
+}
+
+// Build the identifier of an object permission
+func objectPermissionID(data *ObjectPermissionResourceModel) string {
+	principalType, principal := "user_group", data.UserGroup.ValueString()
+	if !data.User.IsNull() {
+		principalType, principal = "user", data.User.ValueString()
+	}
+	return fmt.Sprintf("%s/%d/%s/%s/%s", data.ObjectType.ValueString(), data.ObjectId.ValueInt64(), principalType, principal,
+		data.Permission.ValueString())
+}
@@ -0,0 +1,157 @@
+package hwmux
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccObjectPermissionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "hwmux_permission_group" "test" {
+	name = "test_object_permission"
+}
+
+resource "hwmux_object_permission" "test" {
+	object_type = "device"
+	object_id   = 1
+	user_group  = hwmux_permission_group.test.name
+	permission  = "change"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hwmux_object_permission.test", "id", "device/1/user_group/test_object_permission/change"),
+					resource.TestCheckNoResourceAttr("hwmux_object_permission.test", "user"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "hwmux_object_permission.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestObjectPermissionKeepsOtherGrants(t *testing.T) {
+	ctx := context.Background()
+	var patched map[string]interface{}
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if req.URL.Path != "/api/labels/3/permissions/" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		if req.Method == http.MethodPatch {
+			json.NewDecoder(req.Body).Decode(&patched)
+		}
+		w.Write([]byte(`{"users": {"alice": ["view"]}, "user_groups": {"Team 1": ["view"], "Team 2": ["view", "change"]}}`))
+	})
+
+	r := NewObjectPermissionResource().(*ObjectPermissionResource)
+	r.Configure(ctx, tfresource.ConfigureRequest{ProviderData: client}, &tfresource.ConfigureResponse{})
+
+	data := &ObjectPermissionResourceModel{
+		ObjectType: types.StringValue("label"),
+		ObjectId:   types.Int64Value(3),
+		UserGroup:  types.StringValue("Team 1"),
+		User:       types.StringNull(),
+		Permission: types.StringValue("delete"),
+	}
+	var diagnostics diag.Diagnostics
+	_, err := r.updateObjectPermissions(ctx, &diagnostics, data, func(permissions []string) []string {
+		return append(permissions, "delete")
+	})
+	if err != nil || diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v %v", err, diagnostics)
+	}
+
+	expected := map[string]interface{}{
+		"user_groups": map[string]interface{}{
+			"Team 1": []interface{}{"view", "delete"},
+			"Team 2": []interface{}{"view", "change"},
+		},
+	}
+	if !reflect.DeepEqual(patched, expected) {
+		t.Fatalf("expected patch %v, got %v", expected, patched)
+	}
+}
+
+func TestObjectPermissionImportState(t *testing.T) {
+	ctx := context.Background()
+	r := NewObjectPermissionResource().(*ObjectPermissionResource)
+
+	testCases := map[string]struct {
+		id          string
+		expected    ObjectPermissionResourceModel
+		expectError bool
+	}{
+		"user group": {id: "device/12/user_group/Team/1/view", expected: ObjectPermissionResourceModel{
+			ObjectType: types.StringValue("device"), ObjectId: types.Int64Value(12),
+			UserGroup: types.StringValue("Team/1"), User: types.StringNull(), Permission: types.StringValue("view"),
+		}},
+		"user": {id: "label/3/user/alice/change", expected: ObjectPermissionResourceModel{
+			ObjectType: types.StringValue("label"), ObjectId: types.Int64Value(3),
+			UserGroup: types.StringNull(), User: types.StringValue("alice"), Permission: types.StringValue("change"),
+		}},
+		"missing permission": {id: "device/12/user/alice", expectError: true},
+		"bad principal":      {id: "device/12/team/alice/view", expectError: true},
+		"bad object id":      {id: "device/abc/user/alice/view", expectError: true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &tfresource.ImportStateResponse{State: newFakeResourceState(t, r, map[string]string{})}
+			r.ImportState(ctx, tfresource.ImportStateRequest{ID: tc.id}, resp)
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Fatalf("expected error %t, got %v", tc.expectError, resp.Diagnostics)
+			}
+			if tc.expectError {
+				return
+			}
+
+			var data ObjectPermissionResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unable to read the imported state: %v", resp.Diagnostics)
+			}
+			tc.expected.ID = types.StringValue(tc.id)
+			tc.expected.Timeouts = data.Timeouts
+			if !reflect.DeepEqual(data, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, data)
+			}
+		})
+	}
+}
+
+func TestObjectPermissionLevelValidator(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &tfresource.SchemaResponse{}
+	NewObjectPermissionResource().Schema(ctx, tfresource.SchemaRequest{}, schemaResp)
+	validators := schemaResp.Schema.Attributes["permission"].(schema.StringAttribute).Validators
+
+	for value, valid := range map[string]bool{"view": true, "delete": true, "reserve": false} {
+		resp := &validator.StringResponse{}
+		for _, v := range validators {
+			v.ValidateString(ctx, validator.StringRequest{Path: path.Root("permission"), ConfigValue: types.StringValue(value)}, resp)
+		}
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("expected %q to be valid: %t, got %v", value, valid, resp.Diagnostics)
+		}
+	}
+}
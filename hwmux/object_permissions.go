@@ -0,0 +1,72 @@
+package hwmux
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Attribute types of the permissions attribute of the data sources
+var objectPermissionsAttrTypes = map[string]attr.Type{
+	"user_groups": types.MapType{ElemType: types.ListType{ElemType: types.StringType}},
+	"users":       types.MapType{ElemType: types.ListType{ElemType: types.StringType}},
+}
+
+// Schema of the permissions attribute shared by the data sources of objects with object-level permissions
+func objectPermissionsDataSourceAttribute(objectName string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: fmt.Sprintf("The object-level permissions of the %s.", objectName),
+		Computed:            true,
+		Attributes: map[string]schema.Attribute{
+			"user_groups": schema.MapAttribute{
+				MarkdownDescription: "The permissions held by each user group, keyed by group name.",
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Computed:            true,
+			},
+			"users": schema.MapAttribute{
+				MarkdownDescription: "The permissions held by each user, keyed by username.",
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Convert the object-level permissions returned by hwmux to the value of the permissions attribute
+func objectPermissionsValue(ctx context.Context, objectPerms *hwmux.ObjectPermissions, diagnostics *diag.Diagnostics) (
+	types.Object, error) {
+	userGroups, diags := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, sortedObjectPermissions(objectPerms.GetUserGroups()))
+	diagnostics.Append(diags...)
+	users, diags := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, sortedObjectPermissions(objectPerms.GetUsers()))
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return types.ObjectNull(objectPermissionsAttrTypes), fmt.Errorf("unable to convert the object permissions")
+	}
+
+	value, diags := types.ObjectValue(objectPermissionsAttrTypes, map[string]attr.Value{
+		"user_groups": userGroups,
+		"users":       users,
+	})
+	diagnostics.Append(diags...)
+	if diags.HasError() {
+		return types.ObjectNull(objectPermissionsAttrTypes), fmt.Errorf("unable to convert the object permissions")
+	}
+	return value, nil
+}
+
+// Get the sorted permissions of every user or user group
+func sortedObjectPermissions(grants map[string]interface{}) map[string][]string {
+	result := make(map[string][]string, len(grants))
+	for principal := range grants {
+		permissions := objectPermissionsOf(grants, principal)
+		sort.Strings(permissions)
+		result[principal] = permissions
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package hwmux
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestObjectPermissionsValue(t *testing.T) {
+	ctx := context.Background()
+	objectPerms := hwmux.NewObjectPermissions(
+		map[string]interface{}{"alice": []interface{}{"view", "change"}},
+		map[string]interface{}{"Team 1": []interface{}{"view"}, "Team 2": []interface{}{}},
+	)
+
+	var diagnostics diag.Diagnostics
+	value, err := objectPermissionsValue(ctx, objectPerms, &diagnostics)
+	if err != nil || diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v %v", err, diagnostics)
+	}
+
+	var permissions struct {
+		UserGroups map[string][]string `tfsdk:"user_groups"`
+		Users      map[string][]string `tfsdk:"users"`
+	}
+	diagnostics.Append(value.As(ctx, &permissions, basetypes.ObjectAsOptions{})...)
+	if diagnostics.HasError() {
+		t.Fatalf("unable to read the permissions: %v", diagnostics)
+	}
+
+	expectedUserGroups := map[string][]string{"Team 1": {"view"}, "Team 2": {}}
+	if !reflect.DeepEqual(permissions.UserGroups, expectedUserGroups) {
+		t.Errorf("expected user groups %v, got %v", expectedUserGroups, permissions.UserGroups)
+	}
+	expectedUsers := map[string][]string{"alice": {"change", "view"}}
+	if !reflect.DeepEqual(permissions.Users, expectedUsers) {
+		t.Errorf("expected users %v, got %v", expectedUsers, permissions.Users)
+	}
+}
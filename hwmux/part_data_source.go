@@ -7,6 +7,7 @@ import (
 	"github.com/Silabs-UTF/hwmux-client-golang/v2"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -119,26 +120,29 @@ func (d *PartDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	part, _, err := GetPart(d.client, &resp.Diagnostics, data.Part_no.ValueString())
+	part, _, err := GetPart(ctx, d.client, &resp.Diagnostics, data.Part_no.ValueString())
 	if err != nil {
 		return
 	}
 
-	// Map response body to model
+	err = updatePartDataSourceModelFromResponse(part, &data, &resp.Diagnostics)
+	if err != nil {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Map response body to the data source model
+func updatePartDataSourceModelFromResponse(part *hwmux.Part, data *PartDataSourceModel, diagnostics *diag.Diagnostics) error {
 	data.ID = types.StringValue(part.GetPartNo())
 	data.Part_no = types.StringValue(part.GetPartNo())
 	data.Board_no = types.StringValue(part.GetBoardNo())
 	data.Chip_no = types.StringValue(part.GetChipNo())
 	data.Variant = types.StringValue(part.GetVariant())
 	data.Revision = types.StringValue(part.GetRevision())
-
-	err = MarshalMetadataSetError(part.GetMetadata(), &resp.Diagnostics, "part", &data.Metadata)
-	if err != nil {
-		return
-	}
-
 	data.Part_family = &nestedPartFamilyModel{Name: types.StringValue(part.PartFamily.GetName())}
 
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	return MarshalMetadataSetError(part.GetMetadata(), diagnostics, "part", &data.Metadata)
 }
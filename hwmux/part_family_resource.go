@@ -0,0 +1,316 @@
+package hwmux
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &PartFamilyResource{}
+var _ resource.ResourceWithImportState = &PartFamilyResource{}
+
+func NewPartFamilyResource() resource.Resource {
+	return &PartFamilyResource{}
+}
+
+// PartFamilyResource defines the resource implementation.
+type PartFamilyResource struct {
+	client *hwmux.APIClient
+}
+
+// PartFamilyResourceModel describes the resource data model.
+type PartFamilyResourceModel struct {
+	ID            types.String   `tfsdk:"id"`
+	Name          types.String   `tfsdk:"name"`
+	Regex_pattern types.String   `tfsdk:"regex_pattern"`
+	Description   types.String   `tfsdk:"description"`
+	Metadata      types.String   `tfsdk:"metadata"`
+	LastUpdated   types.String   `tfsdk:"last_updated"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *PartFamilyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_part_family"
+}
+
+func (r *PartFamilyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Part family resource",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Part family identifier. Always equals the name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Part family name. Must be unique. Changing it forces the creation of a new part family.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.LengthAtMost(100),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"regex_pattern": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Regular expression matching the part numbers of the family.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Part family description.",
+			},
+			"metadata": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Part family metadata.",
+				PlanModifiers: []planmodifier.String{
+					metadataSemanticEquality{},
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Description: "Timestamp of the last Terraform update of the resource.",
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *PartFamilyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hwmux.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *hwmux.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PartFamilyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *PartFamilyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "create", defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	partFamily, err := createPartFamilyFromPlan(data, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create part family API request based on plan", err.Error(),
+		)
+		return
+	}
+
+	// create new part family
+	partFamily, httpRes, err := r.client.PartFamiliesApi.PartFamiliesCreate(ctx).PartFamily(*partFamily).Execute()
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error creating part family %s", data.Name.String()),
+			fmt.Sprintf("Could not create part family %s, unexpected error: %s\n%s", data.Name.String(), err.Error(), ResponseBodyToString(httpRes)),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	err = updatePartFamilyModelFromResponse(partFamily, data, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Updating the part family model failed", err.Error(),
+		)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PartFamilyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *PartFamilyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "read", defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	// Get refreshed part family value from hwmux
+	var readDiagnostics diag.Diagnostics
+	partFamily, httpRes, err := GetPartFamily(ctx, r.client, &readDiagnostics, data.ID.ValueString())
+	if removeResourceIfNotFound(ctx, httpRes, readDiagnostics, resp, "Part Family") {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error reading part family %s", data.ID.String()),
+			fmt.Sprintf("Could not read part family %s, unexpected error: %s", data.ID.String(), err.Error()),
+		)
+		return
+	}
+
+	// Map response body to model
+	data.ID = types.StringValue(partFamily.GetName())
+	data.Name = types.StringValue(partFamily.GetName())
+	data.Regex_pattern = NullableStringValue(partFamily.RegexPattern)
+	data.Description = types.StringValue(partFamily.GetDescription())
+
+	err = MarshalMetadataSetError(partFamily.GetMetadata(), &resp.Diagnostics, "part family", &data.Metadata)
+	if err != nil {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PartFamilyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *PartFamilyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "update", defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	partFamily, err := createPartFamilyFromPlan(data, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create part family API request based on plan", err.Error(),
+		)
+		return
+	}
+
+	// update part family
+	partFamily, httpRes, err := r.client.PartFamiliesApi.PartFamiliesUpdate(ctx, data.ID.ValueString()).PartFamily(*partFamily).Execute()
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating part family "+data.ID.String(),
+			fmt.Sprintf("Could not update part family %s, unexpected error: %s\n%s", data.ID.String(), err.Error(), ResponseBodyToString(httpRes)),
+		)
+		return
+	}
+
+	// set model based on response
+	err = updatePartFamilyModelFromResponse(partFamily, data, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Updating the part family model failed", err.Error(),
+		)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PartFamilyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *PartFamilyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "delete", defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	// Delete existing
+	httpRes, err := r.client.PartFamiliesApi.PartFamiliesDestroy(ctx, data.ID.ValueString()).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting part family "+data.ID.String(),
+			fmt.Sprintf("Could not delete part family %s, unexpected error: %s\n%s", data.ID.String(), err.Error(), ResponseBodyToString(httpRes)),
+		)
+		return
+	}
+}
+
+func (r *PartFamilyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Create a PartFamily based on a terraform plan
+func createPartFamilyFromPlan(plan *PartFamilyResourceModel, diagnostics *diag.Diagnostics) (*hwmux.PartFamily, error) {
+	partFamily := hwmux.NewPartFamily(plan.Name.ValueString())
+	partFamily.RegexPattern = NullableStringFromValue(plan.Regex_pattern)
+
+	if !plan.Description.IsUnknown() {
+		partFamily.SetDescription(plan.Description.ValueString())
+	}
+
+	if !plan.Metadata.IsUnknown() {
+		metadata, errorMet := UnmarshalMetadataSetError(plan.Metadata.ValueString(), diagnostics, "part family")
+		if errorMet != nil {
+			return nil, errorMet
+		}
+		partFamily.SetMetadata(*metadata)
+	}
+
+	return partFamily, nil
+}
+
+// Map response body to model and populate Computed attribute values
+func updatePartFamilyModelFromResponse(partFamily *hwmux.PartFamily, plan *PartFamilyResourceModel, diagnostics *diag.Diagnostics) (err error) {
+	plan.ID = types.StringValue(partFamily.GetName())
+	plan.Name = types.StringValue(partFamily.GetName())
+	plan.Regex_pattern = NullableStringValue(partFamily.RegexPattern)
+	plan.Description = types.StringValue(partFamily.GetDescription())
+
+	err = MarshalMetadataSetError(partFamily.GetMetadata(), diagnostics, "part family", &plan.Metadata)
+	if err != nil {
+		return
+	}
+
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package hwmux
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPartFamilyResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "hwmux_part_family" "test" {
+	name = "test_part_family_tf"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hwmux_part_family.test", "id", "test_part_family_tf"),
+					resource.TestCheckResourceAttr("hwmux_part_family.test", "name", "test_part_family_tf"),
+					resource.TestCheckNoResourceAttr("hwmux_part_family.test", "regex_pattern"),
+					// Verify the part family item has Computed attributes filled.
+					resource.TestCheckResourceAttr("hwmux_part_family.test", "metadata", "{}"),
+					resource.TestCheckResourceAttrSet("hwmux_part_family.test", "last_updated"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "hwmux_part_family.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The last_updated attribute does not exist in the hwmux
+				// API, therefore there is no value for it during import.
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "hwmux_part_family" "test" {
+	name          = "test_part_family_tf"
+	regex_pattern = "^BRD41[0-9]{2}[A-Z]$"
+	description   = "Radio boards"
+	metadata      = jsonencode({"vendor" = "silabs"})
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hwmux_part_family.test", "regex_pattern", "^BRD41[0-9]{2}[A-Z]$"),
+					resource.TestCheckResourceAttr("hwmux_part_family.test", "description", "Radio boards"),
+					resource.TestCheckResourceAttr("hwmux_part_family.test", "metadata", `{"vendor":"silabs"}`),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,347 @@
+package hwmux
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &PartResource{}
+var _ resource.ResourceWithImportState = &PartResource{}
+
+func NewPartResource() resource.Resource {
+	return &PartResource{}
+}
+
+// PartResource defines the resource implementation.
+type PartResource struct {
+	client *hwmux.APIClient
+}
+
+// PartResourceModel describes the resource data model.
+type PartResourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Part_no     types.String   `tfsdk:"part_no"`
+	Part_family types.String   `tfsdk:"part_family"`
+	Board_no    types.String   `tfsdk:"board_no"`
+	Chip_no     types.String   `tfsdk:"chip_no"`
+	Variant     types.String   `tfsdk:"variant"`
+	Revision    types.String   `tfsdk:"revision"`
+	Metadata    types.String   `tfsdk:"metadata"`
+	LastUpdated types.String   `tfsdk:"last_updated"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *PartResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_part"
+}
+
+func (r *PartResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Part resource",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Part identifier. Always equals the part_no.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"part_no": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Part number. Must be unique. Changing it forces the creation of a new part.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.LengthAtMost(100),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"part_family": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the part family of the part. Changing it forces the creation of a new part.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"board_no": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Board number.",
+			},
+			"chip_no": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Chip number.",
+			},
+			"variant": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Variant.",
+			},
+			"revision": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Revision.",
+			},
+			"metadata": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Part metadata.",
+				PlanModifiers: []planmodifier.String{
+					metadataSemanticEquality{},
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Description: "Timestamp of the last Terraform update of the resource.",
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *PartResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hwmux.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *hwmux.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PartResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *PartResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "create", defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	part, err := createPartFromPlan(data, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create part API request based on plan", err.Error(),
+		)
+		return
+	}
+
+	// create new part
+	part, httpRes, err := r.client.PartFamiliesApi.PartFamiliesPartsCreate(ctx, data.Part_family.ValueString()).Part(*part).Execute()
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error creating part %s", data.Part_no.String()),
+			fmt.Sprintf("Could not create part %s, unexpected error: %s\n%s", data.Part_no.String(), err.Error(), ResponseBodyToString(httpRes)),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	err = updatePartModelFromResponse(part, data, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Updating the part model failed", err.Error(),
+		)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PartResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *PartResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "read", defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	// Get refreshed part value from hwmux
+	var readDiagnostics diag.Diagnostics
+	part, httpRes, err := GetPart(ctx, r.client, &readDiagnostics, data.ID.ValueString())
+	if removeResourceIfNotFound(ctx, httpRes, readDiagnostics, resp, "Part") {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error reading part %s", data.ID.String()),
+			fmt.Sprintf("Could not read part %s, unexpected error: %s", data.ID.String(), err.Error()),
+		)
+		return
+	}
+
+	// Map response body to model
+	data.ID = types.StringValue(part.GetPartNo())
+	data.Part_no = types.StringValue(part.GetPartNo())
+	data.Part_family = types.StringValue(part.PartFamily.GetName())
+	data.Board_no = NullableStringValue(part.BoardNo)
+	data.Chip_no = NullableStringValue(part.ChipNo)
+	data.Variant = types.StringValue(part.GetVariant())
+	data.Revision = types.StringValue(part.GetRevision())
+
+	err = MarshalMetadataSetError(part.GetMetadata(), &resp.Diagnostics, "part", &data.Metadata)
+	if err != nil {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PartResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *PartResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "update", defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	part, err := createPartFromPlan(data, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create part API request based on plan", err.Error(),
+		)
+		return
+	}
+
+	// update part
+	part, httpRes, err := r.client.PartFamiliesApi.PartFamiliesPartsUpdate(ctx, data.ID.ValueString(), data.Part_family.ValueString()).Part(*part).Execute()
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating part "+data.ID.String(),
+			fmt.Sprintf("Could not update part %s, unexpected error: %s\n%s", data.ID.String(), err.Error(), ResponseBodyToString(httpRes)),
+		)
+		return
+	}
+
+	// set model based on response
+	err = updatePartModelFromResponse(part, data, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Updating the part model failed", err.Error(),
+		)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PartResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *PartResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "delete", defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	// Delete existing
+	httpRes, err := r.client.PartFamiliesApi.PartFamiliesPartsDestroy(ctx, data.ID.ValueString(), data.Part_family.ValueString()).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting part "+data.ID.String(),
+			fmt.Sprintf("Could not delete part %s, unexpected error: %s\n%s", data.ID.String(), err.Error(), ResponseBodyToString(httpRes)),
+		)
+		return
+	}
+}
+
+func (r *PartResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Create a Part based on a terraform plan
+func createPartFromPlan(plan *PartResourceModel, diagnostics *diag.Diagnostics) (*hwmux.Part, error) {
+	partFamily := hwmux.NewPartFamily(plan.Part_family.ValueString())
+	part := hwmux.NewPart(plan.Part_no.ValueString(), *partFamily, NullableStringFromValue(plan.Board_no))
+	part.ChipNo = NullableStringFromValue(plan.Chip_no)
+
+	if !plan.Variant.IsUnknown() {
+		part.SetVariant(plan.Variant.ValueString())
+	}
+	if !plan.Revision.IsUnknown() {
+		part.SetRevision(plan.Revision.ValueString())
+	}
+
+	if !plan.Metadata.IsUnknown() {
+		metadata, errorMet := UnmarshalMetadataSetError(plan.Metadata.ValueString(), diagnostics, "part")
+		if errorMet != nil {
+			return nil, errorMet
+		}
+		part.SetMetadata(*metadata)
+	}
+
+	return part, nil
+}
+
+// Map response body to model and populate Computed attribute values
+func updatePartModelFromResponse(part *hwmux.Part, plan *PartResourceModel, diagnostics *diag.Diagnostics) (err error) {
+	plan.ID = types.StringValue(part.GetPartNo())
+	plan.Part_no = types.StringValue(part.GetPartNo())
+	plan.Part_family = types.StringValue(part.PartFamily.GetName())
+	plan.Board_no = NullableStringValue(part.BoardNo)
+	plan.Chip_no = NullableStringValue(part.ChipNo)
+	plan.Variant = types.StringValue(part.GetVariant())
+	plan.Revision = types.StringValue(part.GetRevision())
+
+	err = MarshalMetadataSetError(part.GetMetadata(), diagnostics, "part", &plan.Metadata)
+	if err != nil {
+		return
+	}
+
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package hwmux
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPartResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "hwmux_part_family" "test" {
+	name = "test_part_family_tf"
+}
+
+resource "hwmux_part" "test" {
+	part_no     = "test_part_tf"
+	part_family = hwmux_part_family.test.name
+	board_no    = "BRD4180"
+	chip_no     = "EFR32MG21"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hwmux_part.test", "id", "test_part_tf"),
+					resource.TestCheckResourceAttr("hwmux_part.test", "part_no", "test_part_tf"),
+					resource.TestCheckResourceAttr("hwmux_part.test", "part_family", "test_part_family_tf"),
+					resource.TestCheckResourceAttr("hwmux_part.test", "board_no", "BRD4180"),
+					resource.TestCheckResourceAttr("hwmux_part.test", "chip_no", "EFR32MG21"),
+					// Verify the part item has Computed attributes filled.
+					resource.TestCheckResourceAttr("hwmux_part.test", "metadata", "{}"),
+					resource.TestCheckResourceAttrSet("hwmux_part.test", "last_updated"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "hwmux_part.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The last_updated attribute does not exist in the hwmux
+				// API, therefore there is no value for it during import.
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "hwmux_part_family" "test" {
+	name = "test_part_family_tf"
+}
+
+resource "hwmux_part" "test" {
+	part_no     = "test_part_tf"
+	part_family = hwmux_part_family.test.name
+	board_no    = "BRD4180"
+	chip_no     = "EFR32MG21"
+	variant     = "B"
+	revision    = "B01"
+	metadata    = jsonencode({"radio" = "2.4GHz"})
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hwmux_part.test", "variant", "B"),
+					resource.TestCheckResourceAttr("hwmux_part.test", "revision", "B01"),
+					resource.TestCheckResourceAttr("hwmux_part.test", "metadata", `{"radio":"2.4GHz"}`),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
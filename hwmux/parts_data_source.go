@@ -0,0 +1,182 @@
+package hwmux
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &PartsDataSource{}
+
+func NewPartsDataSource() datasource.DataSource {
+	return &PartsDataSource{}
+}
+
+type PartsDataSource struct {
+	client *hwmux.APIClient
+}
+
+// partsDataSourceModel maps the data source schema data.
+type PartsDataSourceModel struct {
+	ID             types.String            `tfsdk:"id"`
+	Part_family    types.String            `tfsdk:"part_family"`
+	Part_no_regex  types.String            `tfsdk:"part_no_regex"`
+	MetadataFilter map[string]types.String `tfsdk:"metadata"`
+	Parts          []PartDataSourceModel   `tfsdk:"parts"`
+}
+
+func (d *PartsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_parts"
+}
+
+func (d *PartsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Parts data source. Lists all the parts matching the given filters.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder identifier. Set to satisfy terraform restrictions.",
+				Computed:            true,
+			},
+			"part_family": schema.StringAttribute{
+				MarkdownDescription: "Only return the parts that belong to this part family.",
+				Optional:            true,
+			},
+			"part_no_regex": schema.StringAttribute{
+				MarkdownDescription: "Only return the parts whose part number matches this regular expression.",
+				Optional:            true,
+			},
+			"metadata": schema.MapAttribute{
+				MarkdownDescription: "Only return the parts whose metadata contains all of these key/value pairs. " +
+					"Values that are not strings in the metadata are compared using their json encoding.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"parts": schema.ListNestedAttribute{
+				MarkdownDescription: "The parts matching the filters.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Part identifier. Always equals the part_no.",
+							Computed:            true,
+						},
+						"part_no": schema.StringAttribute{
+							MarkdownDescription: "Part number.",
+							Computed:            true,
+						},
+						"board_no": schema.StringAttribute{
+							MarkdownDescription: "Board number.",
+							Computed:            true,
+						},
+						"chip_no": schema.StringAttribute{
+							MarkdownDescription: "Chip number.",
+							Computed:            true,
+						},
+						"revision": schema.StringAttribute{
+							MarkdownDescription: "Part revision.",
+							Computed:            true,
+						},
+						"variant": schema.StringAttribute{
+							MarkdownDescription: "Part variant.",
+							Computed:            true,
+						},
+						"metadata": schema.StringAttribute{
+							MarkdownDescription: "The metadata of the Part.",
+							Computed:            true,
+						},
+						"part_family": schema.SingleNestedAttribute{
+							MarkdownDescription: "The Part Family.",
+							Computed:            true,
+							Attributes: map[string]schema.Attribute{
+								"name": schema.StringAttribute{
+									MarkdownDescription: "The part family name.",
+									Computed:            true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PartsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hwmux.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *hwmux.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PartsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PartsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	partNoRegex, err := regexpFilterFromConfig(data.Part_no_regex, "part_no_regex", &resp.Diagnostics)
+	if err != nil {
+		return
+	}
+
+	// the filters supported by the API are applied server side
+	request := d.client.PartsApi.PartsList(ctx)
+	if !data.Part_family.IsNull() {
+		request = request.PartFamily(data.Part_family.ValueString())
+	}
+
+	parts, err := ListParts(&resp.Diagnostics, request)
+	if err != nil {
+		return
+	}
+
+	metadataFilter := metadataFilterFromConfig(data.MetadataFilter)
+
+	// the remaining filters are applied client side
+	data.Parts = []PartDataSourceModel{}
+	for i := range parts {
+		part := &parts[i]
+		if partNoRegex != nil && !partNoRegex.MatchString(part.GetPartNo()) {
+			continue
+		}
+		if !MetadataMatches(part.GetMetadata(), metadataFilter) {
+			continue
+		}
+
+		var partModel PartDataSourceModel
+		err = updatePartDataSourceModelFromResponse(part, &partModel, &resp.Diagnostics)
+		if err != nil {
+			return
+		}
+		data.Parts = append(data.Parts, partModel)
+	}
+
+	data.ID = types.StringValue("parts")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
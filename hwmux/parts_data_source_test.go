@@ -0,0 +1,31 @@
+package hwmux
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPartsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "hwmux_parts" "test" {
+	part_family   = "PartFamily_0"
+	part_no_regex = "^Part_no_0$"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hwmux_parts.test", "parts.#", "1"),
+					resource.TestCheckResourceAttr("data.hwmux_parts.test", "parts.0.id", "Part_no_0"),
+					resource.TestCheckResourceAttr("data.hwmux_parts.test", "parts.0.chip_no", "chip0"),
+					resource.TestCheckResourceAttr("data.hwmux_parts.test", "parts.0.part_family.name", "PartFamily_0"),
+					resource.TestCheckResourceAttrSet("data.hwmux_parts.test", "parts.0.metadata"),
+				),
+			},
+		},
+	})
+}
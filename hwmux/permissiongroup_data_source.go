@@ -85,12 +85,19 @@ func (d *PermissionGroupDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 
-	permissionGroup, _, err := GetPermissionGroup(d.client, &resp.Diagnostics, data.Name.ValueString())
+	permissionGroup, _, err := GetPermissionGroup(ctx, d.client, &resp.Diagnostics, data.Name.ValueString())
 	if err != nil {
 		return
 	}
 
-	// Map response body to model
+	updatePermissionGroupDataSourceModelFromResponse(permissionGroup, &data)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Map response body to the data source model
+func updatePermissionGroupDataSourceModelFromResponse(permissionGroup *hwmux.PermissionGroup, data *PermissionGroupDataSourceModel) {
 	data.Name = types.StringValue(permissionGroup.GetName())
 	data.ID = types.Int64Value(int64(permissionGroup.GetId()))
 
@@ -98,7 +105,4 @@ func (d *PermissionGroupDataSource) Read(ctx context.Context, req datasource.Rea
 	for i, permission := range permissionGroup.GetPermissions() {
 		data.Permissions[i] = types.StringValue(permission)
 	}
-
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
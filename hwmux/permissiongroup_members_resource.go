@@ -0,0 +1,271 @@
+package hwmux
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &PermissionGroupMembersResource{}
+var _ resource.ResourceWithImportState = &PermissionGroupMembersResource{}
+
+func NewPermissionGroupMembersResource() resource.Resource {
+	return &PermissionGroupMembersResource{}
+}
+
+// PermissionGroupMembersResource defines the resource implementation.
+type PermissionGroupMembersResource struct {
+	client *hwmux.APIClient
+}
+
+// PermissionGroupMembersResourceModel describes the resource data model.
+type PermissionGroupMembersResourceModel struct {
+	ID        types.String   `tfsdk:"id"`
+	Group     types.String   `tfsdk:"group"`
+	Usernames []types.String `tfsdk:"usernames"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *PermissionGroupMembersResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_permission_group_members"
+}
+
+func (r *PermissionGroupMembersResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Permission group members resource. Owns the full member list of a permission group: " +
+			"users added to the group outside of this resource are removed from it. " +
+			"Do not combine it with `hwmux_permission_group_membership` resources or with the `permission_groups` of a " +
+			"`hwmux_user` for the same group. Destroying the resource removes the listed users from the group.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the member list. Always equals the group name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the permission group. Changing it forces the creation of a new member list.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"usernames": schema.SetAttribute{
+				MarkdownDescription: "The usernames of all the members of the permission group.",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *PermissionGroupMembersResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hwmux.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *hwmux.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PermissionGroupMembersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *PermissionGroupMembersResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "create", defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	err := r.setMembers(ctx, &resp.Diagnostics, data)
+	if err != nil {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PermissionGroupMembersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *PermissionGroupMembersResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "read", defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	// the member list is gone with its group
+	var readDiagnostics diag.Diagnostics
+	_, httpRes, err := GetPermissionGroup(ctx, r.client, &readDiagnostics, data.Group.ValueString())
+	if removeResourceIfNotFound(ctx, httpRes, readDiagnostics, resp, "Permission Group") {
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	members, err := listPermissionGroupMembers(ctx, r.client, &resp.Diagnostics, data.Group.ValueString())
+	if err != nil {
+		return
+	}
+
+	data.ID = types.StringValue(data.Group.ValueString())
+	data.Usernames = make([]types.String, len(members))
+	for i, username := range members {
+		data.Usernames[i] = types.StringValue(username)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PermissionGroupMembersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *PermissionGroupMembersResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "update", defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	err := r.setMembers(ctx, &resp.Diagnostics, data)
+	if err != nil {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PermissionGroupMembersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *PermissionGroupMembersResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "delete", defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	for _, username := range data.Usernames {
+		var deleteDiagnostics diag.Diagnostics
+		httpRes, err := RemoveUserFromPermissionGroup(ctx, r.client, &deleteDiagnostics, data.Group.ValueString(), username.ValueString())
+		// nothing to remove if the user or the group was deleted
+		if IsNotFound(httpRes) {
+			continue
+		}
+		resp.Diagnostics.Append(deleteDiagnostics...)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (r *PermissionGroupMembersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("group"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// Add the planned users that are not members of the group yet and remove the members that are not planned
+func (r *PermissionGroupMembersResource) setMembers(ctx context.Context, diagnostics *diag.Diagnostics,
+	plan *PermissionGroupMembersResourceModel) error {
+	groupName := plan.Group.ValueString()
+
+	members, err := listPermissionGroupMembers(ctx, r.client, diagnostics, groupName)
+	if err != nil {
+		return err
+	}
+
+	// sets do not exist in go, so we use maps instead
+	existing := make(map[string]bool)
+	desired := make(map[string]bool)
+	for _, username := range members {
+		existing[username] = true
+	}
+	for _, username := range plan.Usernames {
+		desired[username.ValueString()] = true
+	}
+
+	for _, username := range members {
+		if !desired[username] {
+			if _, err := RemoveUserFromPermissionGroup(ctx, r.client, diagnostics, groupName, username); err != nil {
+				return err
+			}
+		}
+	}
+	for _, username := range plan.Usernames {
+		if !existing[username.ValueString()] {
+			if _, err := AddUserToPermissionGroup(ctx, r.client, diagnostics, groupName, username.ValueString()); err != nil {
+				return err
+			}
+		}
+	}
+
+	plan.ID = types.StringValue(groupName)
+	return nil
+}
+
+// Get the sorted usernames of the members of a permission group
+func listPermissionGroupMembers(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics, groupName string) (
+	[]string, error) {
+	users, err := ListUsers(diagnostics, client.UserApi.UserList(ctx).UserGroup(groupName))
+	if err != nil {
+		return nil, err
+	}
+
+	usernames := make([]string, len(users))
+	for i, user := range users {
+		usernames[i] = user.GetUsername()
+	}
+	sort.Strings(usernames)
+	return usernames, nil
+}
@@ -0,0 +1,110 @@
+package hwmux
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPermissionGroupMembersResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "hwmux_permission_group" "test" {
+	name = "test_pg_members"
+}
+
+resource "hwmux_permission_group_members" "test" {
+	group     = hwmux_permission_group.test.name
+	usernames = ["admin"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hwmux_permission_group_members.test", "id", "test_pg_members"),
+					resource.TestCheckResourceAttr("hwmux_permission_group_members.test", "usernames.#", "1"),
+					resource.TestCheckResourceAttr("hwmux_permission_group_members.test", "usernames.0", "admin"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "hwmux_permission_group_members.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "hwmux_permission_group" "test" {
+	name = "test_pg_members"
+}
+
+resource "hwmux_permission_group_members" "test" {
+	group     = hwmux_permission_group.test.name
+	usernames = []
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hwmux_permission_group_members.test", "usernames.#", "0"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestPermissionGroupMembersSetMembers(t *testing.T) {
+	ctx := context.Background()
+	var requests []string
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case req.Method == http.MethodGet:
+			if req.URL.Query().Get("user_group") != "team" {
+				t.Errorf("expected the members to be listed with the group filter, got %q", req.URL.RawQuery)
+			}
+			w.Write([]byte(`{"count": 2, "results": [{"username": "bob"}, {"username": "carol"}]}`))
+		case req.Method == http.MethodPost:
+			var users []map[string]string
+			json.NewDecoder(req.Body).Decode(&users)
+			requests = append(requests, "add "+users[0]["username"])
+			w.Write([]byte(`{"name": "team"}`))
+		case req.Method == http.MethodDelete:
+			requests = append(requests, "remove "+path.Base(req.URL.Path))
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	r := NewPermissionGroupMembersResource()
+	r.(tfresource.ResourceWithConfigure).Configure(ctx, tfresource.ConfigureRequest{ProviderData: client}, &tfresource.ConfigureResponse{})
+
+	plan := &PermissionGroupMembersResourceModel{
+		Group:     types.StringValue("team"),
+		Usernames: []types.String{types.StringValue("alice"), types.StringValue("bob")},
+	}
+	var diagnostics diag.Diagnostics
+	err := r.(*PermissionGroupMembersResource).setMembers(ctx, &diagnostics, plan)
+	if err != nil || diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v %v", err, diagnostics)
+	}
+
+	sort.Strings(requests)
+	expected := []string{"add alice", "remove carol"}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Fatalf("expected requests %v, got %v", expected, requests)
+	}
+	if plan.ID.ValueString() != "team" {
+		t.Fatalf("expected the id to be the group name, got %s", plan.ID)
+	}
+}
@@ -0,0 +1,207 @@
+package hwmux
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &PermissionGroupMembershipResource{}
+var _ resource.ResourceWithImportState = &PermissionGroupMembershipResource{}
+
+func NewPermissionGroupMembershipResource() resource.Resource {
+	return &PermissionGroupMembershipResource{}
+}
+
+// PermissionGroupMembershipResource defines the resource implementation.
+type PermissionGroupMembershipResource struct {
+	client *hwmux.APIClient
+}
+
+// PermissionGroupMembershipResourceModel describes the resource data model.
+type PermissionGroupMembershipResourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	Group    types.String   `tfsdk:"group"`
+	Username types.String   `tfsdk:"username"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *PermissionGroupMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_permission_group_membership"
+}
+
+func (r *PermissionGroupMembershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Permission group membership resource. Adds a single user to a permission group without managing " +
+			"the other members of the group, so that users that are not managed by Terraform, such as SSO accounts, can be added to groups. " +
+			"Do not combine it with `hwmux_permission_group_members` for the same group, or with the `permission_groups` of a " +
+			"`hwmux_user` for the same user.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Membership identifier, formatted as `group/username`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the permission group.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The username of the user to add to the permission group.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *PermissionGroupMembershipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hwmux.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *hwmux.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PermissionGroupMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *PermissionGroupMembershipResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "create", defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	_, err := AddUserToPermissionGroup(ctx, r.client, &resp.Diagnostics, data.Group.ValueString(), data.Username.ValueString())
+	if err != nil {
+		return
+	}
+
+	data.ID = types.StringValue(data.Group.ValueString() + "/" + data.Username.ValueString())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PermissionGroupMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *PermissionGroupMembershipResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "read", defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	// Get refreshed user value from hwmux
+	var readDiagnostics diag.Diagnostics
+	user, httpRes, err := GetUser(ctx, r.client, &readDiagnostics, data.Username.ValueString())
+	if removeResourceIfNotFound(ctx, httpRes, readDiagnostics, resp, "User") {
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	// the membership is gone if the user was removed from the group
+	for _, group := range user.GetGroups() {
+		if group == data.Group.ValueString() {
+			data.ID = types.StringValue(data.Group.ValueString() + "/" + data.Username.ValueString())
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *PermissionGroupMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	updateTimeoutsOnly(req, resp)
+}
+
+func (r *PermissionGroupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *PermissionGroupMembershipResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "delete", defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	var deleteDiagnostics diag.Diagnostics
+	httpRes, _ := RemoveUserFromPermissionGroup(ctx, r.client, &deleteDiagnostics, data.Group.ValueString(), data.Username.ValueString())
+	// nothing to remove if the user or the group was deleted
+	if IsNotFound(httpRes) {
+		return
+	}
+	resp.Diagnostics.Append(deleteDiagnostics...)
+}
+
+func (r *PermissionGroupMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// usernames cannot contain a slash, so the group name is everything before the last one
+	separator := strings.LastIndex(req.ID, "/")
+	if separator <= 0 || separator == len(req.ID)-1 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: group/username. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group"), req.ID[:separator])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("username"), req.ID[separator+1:])...)
+}
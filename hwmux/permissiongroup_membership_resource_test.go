@@ -0,0 +1,53 @@
+package hwmux
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const permissionGroupMembershipConfig = `
+resource "hwmux_permission_group" "test" {
+	name = "test_pg_membership"
+}
+
+resource "hwmux_user" "test" {
+	username          = "test_pg_membership_user"
+	password          = "a_password"
+	permission_groups = ["All users"]
+
+	# the group memberships of the user are managed by hwmux_permission_group_membership
+	lifecycle {
+		ignore_changes = [permission_groups]
+	}
+}
+
+resource "hwmux_permission_group_membership" "test" {
+	group    = hwmux_permission_group.test.name
+	username = hwmux_user.test.username
+}
+`
+
+func TestAccPermissionGroupMembershipResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + permissionGroupMembershipConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hwmux_permission_group_membership.test", "group", "test_pg_membership"),
+					resource.TestCheckResourceAttr("hwmux_permission_group_membership.test", "username", "test_pg_membership_user"),
+					resource.TestCheckResourceAttr("hwmux_permission_group_membership.test", "id", "test_pg_membership/test_pg_membership_user"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "hwmux_permission_group_membership.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -3,22 +3,33 @@ package hwmux
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
 	"strconv"
 	"time"
 
 	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// Django permission codenames, formatted as <action>_<model>
+var permissionCodenameRegex = regexp.MustCompile(`^[a-z]+_[a-z0-9_]+$`)
+
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &PermissionGroupResource{}
 var _ resource.ResourceWithImportState = &PermissionGroupResource{}
+var _ resource.ResourceWithModifyPlan = &PermissionGroupResource{}
 
 func NewPermissionGroupResource() resource.Resource {
 	return &PermissionGroupResource{}
@@ -31,10 +42,11 @@ type PermissionGroupResource struct {
 
 // PermissionGroupResourceModel describes the resource data model.
 type PermissionGroupResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Permissions types.Set    `tfsdk:"permissions"`
-	LastUpdated types.String `tfsdk:"last_updated"`
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Permissions types.Set      `tfsdk:"permissions"`
+	LastUpdated types.String   `tfsdk:"last_updated"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *PermissionGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -59,15 +71,31 @@ func (r *PermissionGroupResource) Schema(ctx context.Context, req resource.Schem
 				MarkdownDescription: "Permission Group name",
 			},
 			"permissions": schema.SetAttribute{
-				MarkdownDescription: "The permissions that this permission group has.",
-				ElementType:         types.StringType,
-				Computed:            true,
+				MarkdownDescription: "The Django model permission codenames that this permission group holds, such as `change_device` " +
+					"or `add_reservation`. When omitted, the permissions are left as they are in hwmux. " +
+					"hwmux has no endpoint listing its permissions, so the plan fails if a permission that is added is not held " +
+					"by any permission group in hwmux. Applying fails if hwmux does not grant one of the permissions, " +
+					"in which case the permissions that were granted are saved in the state.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(permissionCodenameRegex, "must be a permission codename such as change_device"),
+					),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"last_updated": schema.StringAttribute{
 				Description: "Timestamp of the last Terraform update of the resource.",
 				Computed:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -101,7 +129,10 @@ func (r *PermissionGroupResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	permissionGroupSerializer, err := createPermissionGroupFromPlan(data, &resp.Diagnostics)
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "create", defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	permissionGroupSerializer, err := createPermissionGroupFromPlan(ctx, data, &resp.Diagnostics)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to create permissionGroup API request based on plan", err.Error(),
@@ -110,19 +141,21 @@ func (r *PermissionGroupResource) Create(ctx context.Context, req resource.Creat
 	}
 
 	// create new permissionGroup
-	permissionGroupSerializer, httpRes, err := r.client.PermissionsApi.PermissionsGroupsCreate(context.Background()).PermissionGroup(*permissionGroupSerializer).Execute()
+	permissionGroupSerializer, httpRes, err := r.client.PermissionsApi.PermissionsGroupsCreate(ctx).PermissionGroup(*permissionGroupSerializer).Execute()
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating permissionGroup",
-			"Could not create permissionGroup, unexpected error: "+err.Error()+"\n"+BodyToString(&httpRes.Body),
+			"Could not create permissionGroup, unexpected error: "+err.Error()+"\n"+ResponseBodyToString(httpRes),
 		)
 		return
 	}
 
+	plannedPermissions := data.Permissions
+
 	// Map response body to schema and populate Computed attribute values
 	// set model based on response
-	err = updatePermissionGroupModelFromResponse(permissionGroupSerializer, data, &resp.Diagnostics, r.client)
+	err = updatePermissionGroupModelFromResponse(ctx, permissionGroupSerializer, data, &resp.Diagnostics, r.client)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Updating the permissionGroup model failed", err.Error(),
@@ -131,7 +164,10 @@ func (r *PermissionGroupResource) Create(ctx context.Context, req resource.Creat
 	}
 
 	// Save data into Terraform state
+	// with the permissions granted by hwmux, so that the state is right even if some of the planned ones were not granted
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	checkPermissionsGranted(ctx, plannedPermissions, permissionGroupSerializer, &resp.Diagnostics)
 }
 
 func (r *PermissionGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -144,14 +180,21 @@ func (r *PermissionGroupResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "read", defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	// Get refreshed permissionGroup value from hwmux
-	permissionGroup, _, err := GetPermissionGroup(r.client, &resp.Diagnostics, data.ID.ValueString())
+	var readDiagnostics diag.Diagnostics
+	permissionGroup, httpRes, err := GetPermissionGroup(ctx, r.client, &readDiagnostics, data.ID.ValueString())
+	if removeResourceIfNotFound(ctx, httpRes, readDiagnostics, resp, "Permission Group") {
+		return
+	}
 	if err != nil {
 		return
 	}
 
 	// Map response body to model
-	err = updatePermissionGroupModelFromResponse(permissionGroup, data, &resp.Diagnostics, r.client)
+	err = updatePermissionGroupModelFromResponse(ctx, permissionGroup, data, &resp.Diagnostics, r.client)
 	if err != nil {
 		return
 	}
@@ -172,23 +215,32 @@ func (r *PermissionGroupResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
-	permissionGroupSerializer := hwmux.NewPermissionGroupWithDefaults()
-	permissionGroupSerializer.SetName(data.Name.ValueString())
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "update", defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	permissionGroupSerializer, err := createPermissionGroupFromPlan(ctx, data, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create permissionGroup API request based on plan", err.Error(),
+		)
+		return
+	}
 
-	// TODO: implement when available
 	// update permissionGroup
-	permissionGroupSerializer, httpRes, err := r.client.PermissionsApi.PermissionsGroupsUpdate(context.Background(), state.ID.ValueString()).PermissionGroup(*permissionGroupSerializer).Execute()
+	permissionGroupSerializer, httpRes, err := r.client.PermissionsApi.PermissionsGroupsUpdate(ctx, state.ID.ValueString()).PermissionGroup(*permissionGroupSerializer).Execute()
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating permissionGroup "+data.Name.String(),
-			"Could not update permissionGroup, unexpected error: "+err.Error()+"\n"+BodyToString(&httpRes.Body),
+			"Could not update permissionGroup, unexpected error: "+err.Error()+"\n"+ResponseBodyToString(httpRes),
 		)
 		return
 	}
 
+	plannedPermissions := data.Permissions
+
 	// set model based on response
-	err = updatePermissionGroupModelFromResponse(permissionGroupSerializer, data, &resp.Diagnostics, r.client)
+	err = updatePermissionGroupModelFromResponse(ctx, permissionGroupSerializer, data, &resp.Diagnostics, r.client)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Updating the permissionGroup model failed", err.Error(),
@@ -197,7 +249,10 @@ func (r *PermissionGroupResource) Update(ctx context.Context, req resource.Updat
 	}
 
 	// Save updated data into Terraform state
+	// with the permissions granted by hwmux, so that the state is right even if some of the planned ones were not granted
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	checkPermissionsGranted(ctx, plannedPermissions, permissionGroupSerializer, &resp.Diagnostics)
 }
 
 func (r *PermissionGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -210,36 +265,156 @@ func (r *PermissionGroupResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "delete", defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	// Delete existing
-	httpRes, err := r.client.PermissionsApi.PermissionsGroupsDestroy(context.Background(), data.ID.ValueString()).Execute()
+	httpRes, err := r.client.PermissionsApi.PermissionsGroupsDestroy(ctx, data.ID.ValueString()).Execute()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting PermissionGroup",
-			"Could not delete PermissionGroup, unexpected error: "+BodyToString(&httpRes.Body),
+			"Could not delete PermissionGroup, unexpected error: "+ResponseBodyToString(httpRes),
 		)
 		return
 	}
 }
 
+// Fail the plan when permissions that hwmux does not know are added, so that they are not partially granted
+func (r *PermissionGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to check on destruction, or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var planned types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("permissions"), &planned)...)
+	if resp.Diagnostics.HasError() || planned.IsUnknown() || planned.IsNull() {
+		return
+	}
+	var permissions []string
+	resp.Diagnostics.Append(planned.ElementsAs(ctx, &permissions, false)...)
+
+	current := make(map[string]bool)
+	if !req.State.Raw.IsNull() {
+		var state types.Set
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("permissions"), &state)...)
+		var statePermissions []string
+		resp.Diagnostics.Append(state.ElementsAs(ctx, &statePermissions, false)...)
+		for _, permission := range statePermissions {
+			current[permission] = true
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var added []string
+	for _, permission := range permissions {
+		if !current[permission] {
+			added = append(added, permission)
+		}
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	known, err := knownPermissionCodenames(ctx, r.client, &resp.Diagnostics)
+	if err != nil {
+		return
+	}
+	var unknown []string
+	for _, permission := range added {
+		if !known[permission] {
+			unknown = append(unknown, permission)
+		}
+	}
+	if len(unknown) == 0 {
+		return
+	}
+
+	sort.Strings(unknown)
+	resp.Diagnostics.AddAttributeError(path.Root("permissions"), "Unknown permissions",
+		fmt.Sprintf("The permissions %q are not held by any permission group in hwmux. "+
+			"Check the codenames, hwmux has no endpoint listing its permissions so only the ones already granted to a group can be checked.", unknown))
+}
+
 func (r *PermissionGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
 // Create a PermissionGroup based on a terraform plan
-func createPermissionGroupFromPlan(plan *PermissionGroupResourceModel, diagnostics *diag.Diagnostics) (*hwmux.PermissionGroup, error) {
+func createPermissionGroupFromPlan(ctx context.Context, plan *PermissionGroupResourceModel, diagnostics *diag.Diagnostics) (
+	*hwmux.PermissionGroup, error) {
 	permissionGroupSerializer := hwmux.NewPermissionGroupWithDefaults()
 	permissionGroupSerializer.SetName(plan.Name.ValueString())
 
+	if !plan.Permissions.IsUnknown() && !plan.Permissions.IsNull() {
+		var permissions []string
+		diagn := plan.Permissions.ElementsAs(ctx, &permissions, false)
+		diagnostics.Append(diagn...)
+		if diagn.HasError() {
+			return nil, fmt.Errorf("unable to read the planned permissions of permission group %s", plan.Name.ValueString())
+		}
+		permissionGroupSerializer.SetPermissions(permissions)
+	}
+
 	return permissionGroupSerializer, nil
 }
 
+// Get the permission codenames known by hwmux. There is no endpoint listing them, so they are collected
+// from the permissions held by the permission groups.
+func knownPermissionCodenames(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics) (map[string]bool, error) {
+	permissionGroups, err := ListPermissionGroups(diagnostics, client.PermissionsApi.PermissionsGroupsList(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool)
+	for _, permissionGroup := range permissionGroups {
+		for _, permission := range permissionGroup.GetPermissions() {
+			known[permission] = true
+		}
+	}
+	return known, nil
+}
+
+// Sets an error if hwmux did not grant all the planned permissions to the permission group
+func checkPermissionsGranted(ctx context.Context, planned types.Set, permissionGroup *hwmux.PermissionGroup, diagnostics *diag.Diagnostics) error {
+	if planned.IsUnknown() || planned.IsNull() {
+		return nil
+	}
+	var permissions []string
+	diagnostics.Append(planned.ElementsAs(ctx, &permissions, false)...)
+
+	granted := make(map[string]bool)
+	for _, permission := range permissionGroup.GetPermissions() {
+		granted[permission] = true
+	}
+	var missing []string
+	for _, permission := range permissions {
+		if !granted[permission] {
+			missing = append(missing, permission)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	sort.Strings(missing)
+	err := fmt.Errorf("hwmux did not grant the permissions %q to permission group %s. "+
+		"Check that the codenames exist on the server and that the server supports setting group permissions",
+		missing, permissionGroup.GetName())
+	diagnostics.AddAttributeError(path.Root("permissions"), "Permissions not granted", err.Error())
+	return err
+}
+
 // Map response body to model and populate Computed attribute values
-func updatePermissionGroupModelFromResponse(permissionGroup *hwmux.PermissionGroup, plan *PermissionGroupResourceModel, diagnostics *diag.Diagnostics, client *hwmux.APIClient) (err error) {
+func updatePermissionGroupModelFromResponse(ctx context.Context, permissionGroup *hwmux.PermissionGroup, plan *PermissionGroupResourceModel, diagnostics *diag.Diagnostics, client *hwmux.APIClient) (err error) {
 	// Map response body to schema and populate Computed attribute values
 	plan.Name = types.StringValue(permissionGroup.GetName())
 	plan.ID = types.StringValue(strconv.Itoa(int(permissionGroup.GetId())))
 
-	set, diagn := types.SetValueFrom(context.Background(), types.StringType, permissionGroup.GetPermissions())
+	set, diagn := types.SetValueFrom(ctx, types.StringType, permissionGroup.GetPermissions())
 	if diagn.HasError() {
 		diagnostics.Append(diagn...)
 		return
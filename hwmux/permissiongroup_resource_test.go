@@ -1,8 +1,18 @@
 package hwmux
 
 import (
+	"context"
+	"net/http"
 	"testing"
 
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -43,7 +53,160 @@ resource "hwmux_permission_group" "test" {
 					resource.TestCheckResourceAttr("hwmux_permission_group.test", "name", "test_permission_group_tf-2"),
 				),
 			},
+			// Permissions testing
+			{
+				Config: providerConfig + `
+resource "hwmux_permission_group" "test" {
+    name        = "test_permission_group_tf-2"
+    permissions = ["view_device", "change_device"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hwmux_permission_group.test", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr("hwmux_permission_group.test", "permissions.*", "change_device"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestCheckPermissionsGranted(t *testing.T) {
+	ctx := context.Background()
+	permissionGroup := hwmux.NewPermissionGroup(1, []string{"view_device", "change_device"}, "team")
+
+	testCases := map[string]struct {
+		planned     types.Set
+		expectError bool
+	}{
+		"unknown":     {types.SetUnknown(types.StringType), false},
+		"null":        {types.SetNull(types.StringType), false},
+		"granted":     {types.SetValueMust(types.StringType, []attr.Value{types.StringValue("view_device")}), false},
+		"not granted": {types.SetValueMust(types.StringType, []attr.Value{types.StringValue("add_reservation")}), true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diagnostics diag.Diagnostics
+			err := checkPermissionsGranted(ctx, tc.planned, permissionGroup, &diagnostics)
+			if (err != nil) != tc.expectError || diagnostics.HasError() != tc.expectError {
+				t.Fatalf("expected error %t, got %v %v", tc.expectError, err, diagnostics)
+			}
+		})
+	}
+}
+
+func TestPermissionCodenameRegex(t *testing.T) {
+	for _, codename := range []string{"change_device", "add_reservation", "view_device_group"} {
+		if !permissionCodenameRegex.MatchString(codename) {
+			t.Errorf("expected %s to be a valid codename", codename)
+		}
+	}
+	for _, codename := range []string{"", "change", "Change_device", "hwmux.change_device", "change device"} {
+		if permissionCodenameRegex.MatchString(codename) {
+			t.Errorf("expected %s to be an invalid codename", codename)
+		}
+	}
+}
+
+func TestPermissionGroupUpdateSavesGrantedPermissions(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// hwmux ignores the unknown codename
+		w.Write([]byte(`{"id": 1, "name": "team", "permissions": ["view_device"]}`))
+	})
+
+	r := NewPermissionGroupResource()
+	r.(tfresource.ResourceWithConfigure).Configure(ctx, tfresource.ConfigureRequest{ProviderData: client}, &tfresource.ConfigureResponse{})
+
+	schemaResp := &tfresource.SchemaResponse{}
+	r.Schema(ctx, tfresource.SchemaRequest{}, schemaResp)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	timeoutsType := schemaResp.Schema.Blocks["timeouts"].Type().(timeouts.Type)
+	diags := plan.Set(ctx, &PermissionGroupResourceModel{
+		ID:   types.StringValue("1"),
+		Name: types.StringValue("team"),
+		Permissions: types.SetValueMust(types.StringType, []attr.Value{
+			types.StringValue("view_device"), types.StringValue("view_unknown"),
+		}),
+		LastUpdated: types.StringUnknown(),
+		Timeouts:    timeouts.Value{Object: types.ObjectNull(timeoutsType.AttrTypes)},
+	})
+	if diags.HasError() {
+		t.Fatalf("unable to build the plan: %v", diags)
+	}
+
+	state := tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}
+	resp := &tfresource.UpdateResponse{State: state}
+	r.Update(ctx, tfresource.UpdateRequest{Plan: plan, State: state}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatalf("expected an error for the permission that was not granted")
+	}
+	var data PermissionGroupResourceModel
+	resp.State.Get(ctx, &data)
+	expected := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("view_device")})
+	if !data.Permissions.Equal(expected) {
+		t.Errorf("expected the granted permissions to be saved in the state, got %s", data.Permissions)
+	}
+}
+
+func TestPermissionGroupModifyPlanRejectsUnknownPermissions(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			t.Errorf("unexpected %s request while planning", req.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"count": 1, "results": [{"id": 1, "name": "admin", "permissions": ["view_device", "change_device"]}]}`))
+	})
+
+	r := NewPermissionGroupResource()
+	r.(tfresource.ResourceWithConfigure).Configure(ctx, tfresource.ConfigureRequest{ProviderData: client}, &tfresource.ConfigureResponse{})
+
+	schemaResp := &tfresource.SchemaResponse{}
+	r.Schema(ctx, tfresource.SchemaRequest{}, schemaResp)
+	timeoutsType := schemaResp.Schema.Blocks["timeouts"].Type().(timeouts.Type)
+	newPlan := func(permissions ...string) tfsdk.Plan {
+		values := []attr.Value{}
+		for _, permission := range permissions {
+			values = append(values, types.StringValue(permission))
+		}
+		plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		diags := plan.Set(ctx, &PermissionGroupResourceModel{
+			ID:          types.StringUnknown(),
+			Name:        types.StringValue("team"),
+			Permissions: types.SetValueMust(types.StringType, values),
+			LastUpdated: types.StringUnknown(),
+			Timeouts:    timeouts.Value{Object: types.ObjectNull(timeoutsType.AttrTypes)},
+		})
+		if diags.HasError() {
+			t.Fatalf("unable to build the plan: %v", diags)
+		}
+		return plan
+	}
+	emptyState := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+
+	plan := newPlan("view_device", "view_unknown")
+	resp := &tfresource.ModifyPlanResponse{Plan: plan}
+	r.(tfresource.ResourceWithModifyPlan).ModifyPlan(ctx, tfresource.ModifyPlanRequest{Plan: plan, State: emptyState}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("expected an error for the unknown permission")
+	}
+
+	plan = newPlan("view_device", "change_device")
+	resp = &tfresource.ModifyPlanResponse{Plan: plan}
+	r.(tfresource.ResourceWithModifyPlan).ModifyPlan(ctx, tfresource.ModifyPlanRequest{Plan: plan, State: emptyState}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("expected no error for known permissions, got %v", resp.Diagnostics)
+	}
+
+	// a permission already in the state is not checked again
+	plan = newPlan("view_device", "view_removed")
+	state := tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}
+	resp = &tfresource.ModifyPlanResponse{Plan: plan}
+	r.(tfresource.ResourceWithModifyPlan).ModifyPlan(ctx, tfresource.ModifyPlanRequest{Plan: plan, State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("expected no error for the permissions already granted, got %v", resp.Diagnostics)
+	}
+}
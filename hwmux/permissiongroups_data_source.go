@@ -0,0 +1,139 @@
+package hwmux
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &PermissionGroupsDataSource{}
+
+func NewPermissionGroupsDataSource() datasource.DataSource {
+	return &PermissionGroupsDataSource{}
+}
+
+type PermissionGroupsDataSource struct {
+	client *hwmux.APIClient
+}
+
+// permissionGroupsDataSourceModel maps the data source schema data.
+type PermissionGroupsDataSourceModel struct {
+	ID               types.String                     `tfsdk:"id"`
+	Name_regex       types.String                     `tfsdk:"name_regex"`
+	Permission       types.String                     `tfsdk:"permission"`
+	PermissionGroups []PermissionGroupDataSourceModel `tfsdk:"permission_groups"`
+}
+
+func (d *PermissionGroupsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_permission_groups"
+}
+
+func (d *PermissionGroupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Permission groups data source. Lists all the permission groups matching the given filters.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder identifier. Set to satisfy terraform restrictions.",
+				Computed:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only return the permission groups whose name matches this regular expression.",
+				Optional:            true,
+			},
+			"permission": schema.StringAttribute{
+				MarkdownDescription: "Only return the permission groups that are assigned this permission.",
+				Optional:            true,
+			},
+			"permission_groups": schema.ListNestedAttribute{
+				MarkdownDescription: "The permission groups matching the filters.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Permission group name",
+							Computed:            true,
+						},
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "Permission group identifier",
+							Computed:            true,
+						},
+						"permissions": schema.SetAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "Permissions assigned to this permission group",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PermissionGroupsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hwmux.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *hwmux.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PermissionGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PermissionGroupsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, err := regexpFilterFromConfig(data.Name_regex, "name_regex", &resp.Diagnostics)
+	if err != nil {
+		return
+	}
+
+	// the API does not support any filter, they are all applied client side
+	permissionGroups, err := ListPermissionGroups(&resp.Diagnostics, d.client.PermissionsApi.PermissionsGroupsList(ctx))
+	if err != nil {
+		return
+	}
+
+	data.PermissionGroups = []PermissionGroupDataSourceModel{}
+	for i := range permissionGroups {
+		permissionGroup := &permissionGroups[i]
+		if nameRegex != nil && !nameRegex.MatchString(permissionGroup.GetName()) {
+			continue
+		}
+		if !data.Permission.IsNull() && !containsString(permissionGroup.GetPermissions(), data.Permission.ValueString()) {
+			continue
+		}
+
+		var permissionGroupModel PermissionGroupDataSourceModel
+		updatePermissionGroupDataSourceModelFromResponse(permissionGroup, &permissionGroupModel)
+		data.PermissionGroups = append(data.PermissionGroups, permissionGroupModel)
+	}
+
+	data.ID = types.StringValue("permission_groups")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,29 @@
+package hwmux
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPermissionGroupsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "hwmux_permission_groups" "test" {
+	name_regex = "^All users$"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hwmux_permission_groups.test", "permission_groups.#", "1"),
+					resource.TestCheckResourceAttr("data.hwmux_permission_groups.test", "permission_groups.0.name", "All users"),
+					resource.TestCheckResourceAttrSet("data.hwmux_permission_groups.test", "permission_groups.0.id"),
+					resource.TestCheckResourceAttrSet("data.hwmux_permission_groups.test", "permission_groups.0.permissions.#"),
+				),
+			},
+		},
+	})
+}
@@ -3,13 +3,16 @@ package hwmux
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -27,8 +30,11 @@ type HwmuxProvider struct {
 
 // HwmuxProviderModel describes the provider data model.
 type HwmuxProviderModel struct {
-	Host  types.String `tfsdk:"host"`
-	Token types.String `tfsdk:"token"`
+	Host         types.String `tfsdk:"host"`
+	Token        types.String `tfsdk:"token"`
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMin types.Int64  `tfsdk:"retry_wait_min"`
+	RetryWaitMax types.Int64  `tfsdk:"retry_wait_max"`
 }
 
 func (p *HwmuxProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -49,6 +55,27 @@ func (p *HwmuxProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of times an idempotent request is retried after a transient failure (connection error, 429, 502, 503 or 504). Defaults to 3. Set to 0 to disable retries.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_wait_min": schema.Int64Attribute{
+				MarkdownDescription: "Minimum time in seconds to wait before retrying a request. The wait time doubles on every attempt. Defaults to 1.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_wait_max": schema.Int64Attribute{
+				MarkdownDescription: "Maximum time in seconds to wait before retrying a request. Also caps the delay requested by a `Retry-After` header sent by hwmux. Defaults to 30.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
 		},
 	}
 }
@@ -121,6 +148,30 @@ func (p *HwmuxProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		)
 	}
 
+	maxRetries := defaultMaxRetries
+	retryWaitMin := defaultRetryWaitMin
+	retryWaitMax := defaultRetryWaitMax
+
+	if !data.MaxRetries.IsNull() && !data.MaxRetries.IsUnknown() {
+		maxRetries = int(data.MaxRetries.ValueInt64())
+	}
+
+	if !data.RetryWaitMin.IsNull() && !data.RetryWaitMin.IsUnknown() {
+		retryWaitMin = time.Duration(data.RetryWaitMin.ValueInt64()) * time.Second
+	}
+
+	if !data.RetryWaitMax.IsNull() && !data.RetryWaitMax.IsUnknown() {
+		retryWaitMax = time.Duration(data.RetryWaitMax.ValueInt64()) * time.Second
+	}
+
+	if retryWaitMin > retryWaitMax {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_min"),
+			"Invalid hwmux retry configuration",
+			"The retry_wait_min value must be lower than or equal to the retry_wait_max value.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -135,6 +186,7 @@ func (p *HwmuxProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	clientConfig := hwmux.NewConfiguration()
 	clientConfig.AddDefaultHeader("Authorization", "Token "+token)
 	clientConfig.Servers = hwmux.ServerConfigurations{hwmux.ServerConfiguration{URL: host}}
+	clientConfig.HTTPClient = newRetryingHTTPClient(maxRetries, retryWaitMin, retryWaitMax)
 	client := hwmux.NewAPIClient(clientConfig)
 
 	resp.DataSourceData = client
@@ -145,21 +197,38 @@ func (p *HwmuxProvider) Resources(ctx context.Context) []func() resource.Resourc
 	return []func() resource.Resource{
 		NewDeviceResource,
 		NewDeviceGroupResource,
+		NewDeviceGroupMembershipResource,
+		NewLabelAttachmentResource,
+		NewPermissionGroupMembershipResource,
+		NewPermissionGroupMembersResource,
+		NewObjectPermissionResource,
+		NewReservationResource,
 		NewLabelResource,
 		NewPermissionGroupResource,
 		NewUserResource,
 		NewTokenResource,
+		NewRoomResource,
+		NewPartFamilyResource,
+		NewPartResource,
 	}
 }
 
 func (p *HwmuxProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewDeviceDataSource,
+		NewDevicesDataSource,
 		NewDeviceGroupDataSource,
+		NewDeviceGroupsDataSource,
 		NewLabelDataSource,
+		NewLabelsDataSource,
 		NewPermissionGroupDataSource,
+		NewPermissionGroupsDataSource,
 		NewPartDataSource,
+		NewPartsDataSource,
 		NewRoomDataSource,
+		NewRoomsDataSource,
+		NewUserDataSource,
+		NewUsersDataSource,
 	}
 }
 
@@ -0,0 +1,480 @@
+package hwmux
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// How often the state of a pending reservation is polled
+var reservationPollInterval = 5 * time.Second
+
+// How long cancelling a reservation that did not become active may take, once the create timeout is over
+var reservationCancelTimeout = 30 * time.Second
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ReservationResource{}
+var _ resource.ResourceWithImportState = &ReservationResource{}
+
+func NewReservationResource() resource.Resource {
+	return &ReservationResource{}
+}
+
+// ReservationResource defines the resource implementation.
+type ReservationResource struct {
+	client *hwmux.APIClient
+}
+
+// ReservationResourceModel describes the resource data model.
+type ReservationResourceModel struct {
+	ID                    types.String   `tfsdk:"id"`
+	DeviceGroups          []types.Int64  `tfsdk:"device_groups"`
+	Devices               []types.Int64  `tfsdk:"devices"`
+	Details               types.String   `tfsdk:"details"`
+	LeaseDurationS        types.Int64    `tfsdk:"lease_duration_s"`
+	State                 types.String   `tfsdk:"state"`
+	LeaseExpires          types.String   `tfsdk:"lease_expires"`
+	AllocatedDevices      []types.Int64  `tfsdk:"allocated_devices"`
+	AllocatedDeviceGroups []types.Int64  `tfsdk:"allocated_device_groups"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *ReservationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reservation"
+}
+
+func (r *ReservationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Reservation resource. Reserves device groups or devices in hwmux. Creating the resource waits until the " +
+			"reservation is active, within the create timeout, and destroying it releases the reservation. " +
+			"A reservation that ended in hwmux, for instance because its lease expired, is created again on the next apply.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Reservation identifier (UUID).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"device_groups": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the device groups to reserve. At least one of `device_groups` or `devices` must be set.",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.AtLeastOneOf(path.MatchRoot("device_groups"), path.MatchRoot("devices")),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"devices": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the devices to reserve. At least one of `device_groups` or `devices` must be set.",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"details": schema.StringAttribute{
+				MarkdownDescription: "The reason for the reservation, such as the pipeline holding the hardware.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(255),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"lease_duration_s": schema.Int64Attribute{
+				MarkdownDescription: "The duration of the reservation lease in seconds, after which hwmux expires the reservation. " +
+					"The reservation does not expire when omitted. Changing it extends the lease in place, so that it expires " +
+					"this many seconds after the apply. hwmux cannot remove the lease of a reservation, so removing it " +
+					"replaces the reservation.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 2147483647),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIf(leaseRemoved,
+						"hwmux cannot remove the lease of a reservation, removing it replaces the reservation.",
+						"hwmux cannot remove the lease of a reservation, removing it replaces the reservation."),
+				},
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "The state of the reservation in hwmux.",
+				Computed:            true,
+			},
+			"lease_expires": schema.StringAttribute{
+				MarkdownDescription: "The time at which the lease of the reservation expires, in RFC 3339 format. Empty when the lease does not expire.",
+				Computed:            true,
+			},
+			"allocated_devices": schema.ListAttribute{
+				MarkdownDescription: "The IDs of the devices allocated to the reservation.",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
+			"allocated_device_groups": schema.ListAttribute{
+				MarkdownDescription: "The IDs of the device groups allocated to the reservation.",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *ReservationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hwmux.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *hwmux.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ReservationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ReservationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "create", defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	// create new reservation
+	reservation, httpRes, err := r.client.ReservationsApi.ReservationsCreate(ctx).ReservationRequest(*createReservationFromPlan(data)).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating reservation",
+			"Could not create reservation, unexpected error: "+err.Error()+"\n"+ResponseBodyToString(httpRes),
+		)
+		return
+	}
+
+	reservation, err = waitForReservationActive(ctx, r.client, &resp.Diagnostics, reservation)
+	if err != nil {
+		// do not leave a queued reservation behind, the resource is not saved in the state
+		cancelWaitingReservation(r.client, &resp.Diagnostics, reservation)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	updateReservationModelFromResponse(reservation, data)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ReservationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ReservationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "read", defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	// Get refreshed reservation value from hwmux
+	var readDiagnostics diag.Diagnostics
+	reservation, httpRes, err := GetReservation(ctx, r.client, &readDiagnostics, data.ID.ValueString())
+	if removeResourceIfNotFound(ctx, httpRes, readDiagnostics, resp, "Reservation") {
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	// the hardware is not held anymore once the reservation ended
+	if isReservationEnded(reservationState(reservation)) {
+		tflog.Warn(ctx, "Reservation ended in hwmux, removing it from the state", map[string]interface{}{
+			"id":    reservation.GetId(),
+			"state": reservationState(reservation),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Map response body to model
+	data.Details = types.StringValue(reservation.GetDetails())
+	data.DeviceGroups = int32sToInt64Values(reservation.GetRDeviceGroups(), data.DeviceGroups == nil)
+	data.Devices = int32sToInt64Values(reservation.GetRDevices(), data.Devices == nil)
+	updateReservationModelFromResponse(reservation, data)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ReservationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *ReservationResourceModel
+	var state *ReservationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// lease_duration_s is the only attribute updated in place, the other ones except timeouts require a replacement
+	if data.LeaseDurationS.Equal(state.LeaseDurationS) {
+		data.State = state.State
+		data.LeaseExpires = state.LeaseExpires
+		data.AllocatedDevices = state.AllocatedDevices
+		data.AllocatedDeviceGroups = state.AllocatedDeviceGroups
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "update", defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	// the lease restarts from now with the new duration, instead of adding it to the remaining lease
+	extensionRequest := hwmux.NewReservationExtensionRequest(int32(data.LeaseDurationS.ValueInt64()))
+	extensionRequest.SetExtendExisting(false)
+	reservation, httpRes, err := r.client.ReservationsApi.ReservationsExtendUpdate(ctx, data.ID.ValueString()).
+		ReservationExtensionRequest(*extensionRequest).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error extending reservation "+data.ID.String(),
+			fmt.Sprintf("Could not extend the lease of reservation %s, unexpected error: %s\n%s", data.ID.String(), err.Error(), ResponseBodyToString(httpRes)),
+		)
+		return
+	}
+
+	updateReservationModelFromResponse(reservation, data)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ReservationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ReservationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "delete", defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	var deleteDiagnostics diag.Diagnostics
+	reservation, httpRes, err := GetReservation(ctx, r.client, &deleteDiagnostics, data.ID.ValueString())
+	// nothing to release if the reservation was deleted
+	if IsNotFound(httpRes) {
+		return
+	}
+	resp.Diagnostics.Append(deleteDiagnostics...)
+	if err != nil {
+		return
+	}
+
+	state := reservationState(reservation)
+	if isReservationEnded(state) {
+		return
+	}
+
+	// active reservations are released, the others are still waiting for hardware and are cancelled
+	if state == string(hwmux.STATEENUM_ACT) {
+		_, httpRes, err = r.client.ReservationsApi.ReservationsReleaseUpdate(ctx, data.ID.ValueString()).Execute()
+	} else {
+		_, httpRes, err = r.client.ReservationsApi.ReservationsCancelUpdate(ctx, data.ID.ValueString()).Execute()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error releasing reservation "+data.ID.String(),
+			fmt.Sprintf("Could not release reservation %s, unexpected error: %s\n%s", data.ID.String(), err.Error(), ResponseBodyToString(httpRes)),
+		)
+		return
+	}
+}
+
+func (r *ReservationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Create a ReservationRequest based on a terraform plan
+func createReservationFromPlan(plan *ReservationResourceModel) *hwmux.ReservationRequest {
+	reservationRequest := hwmux.NewReservationRequest()
+	reservationRequest.SetDetails(plan.Details.ValueString())
+
+	deviceGroups := make([]int32, len(plan.DeviceGroups))
+	for i, deviceGroup := range plan.DeviceGroups {
+		deviceGroups[i] = int32(deviceGroup.ValueInt64())
+	}
+	reservationRequest.SetRDeviceGroups(deviceGroups)
+
+	devices := make([]int32, len(plan.Devices))
+	for i, device := range plan.Devices {
+		devices[i] = int32(device.ValueInt64())
+	}
+	reservationRequest.SetRDevices(devices)
+
+	// Terraform does not send heartbeats, so the reservation must not be expired by the watchdog
+	reservationRequest.SetUseWatchdog(false)
+
+	if !plan.LeaseDurationS.IsNull() {
+		reservationRequest.SetLeaseDurationS(int32(plan.LeaseDurationS.ValueInt64()))
+	} else {
+		reservationRequest.SetLeaseDurationSNil()
+	}
+
+	return reservationRequest
+}
+
+// Map response body to model and populate Computed attribute values
+func updateReservationModelFromResponse(reservation *hwmux.ReservationSessionSerializerReadOnly, plan *ReservationResourceModel) {
+	plan.ID = types.StringValue(reservation.GetId())
+	plan.State = types.StringValue(reservationState(reservation))
+	plan.LeaseExpires = types.StringValue("")
+	if leaseExpires, ok := reservation.GetTLeaseExpiresOk(); ok && leaseExpires != nil {
+		plan.LeaseExpires = types.StringValue(leaseExpires.Format(time.RFC3339))
+	}
+	plan.AllocatedDevices = int32sToInt64Values(reservation.GetADevices(), false)
+	plan.AllocatedDeviceGroups = int32sToInt64Values(reservation.GetADeviceGroups(), false)
+}
+
+// Poll the reservation until it is active. Returns an error if it ended before becoming active or if the context is done.
+func waitForReservationActive(ctx context.Context, client *hwmux.APIClient, diagnostics *diag.Diagnostics,
+	reservation *hwmux.ReservationSessionSerializerReadOnly) (*hwmux.ReservationSessionSerializerReadOnly, error) {
+	ticker := time.NewTicker(reservationPollInterval)
+	defer ticker.Stop()
+
+	for {
+		state := reservationState(reservation)
+		if state == string(hwmux.STATEENUM_ACT) {
+			return reservation, nil
+		}
+		if isReservationEnded(state) {
+			err := fmt.Errorf("reservation %s ended in state %s before becoming active: %s", reservation.GetId(), state,
+				reservation.GetResponseMessage())
+			diagnostics.AddError("Reservation failed", err.Error())
+			return reservation, err
+		}
+
+		tflog.Debug(ctx, "Waiting for reservation to become active", map[string]interface{}{
+			"id":             reservation.GetId(),
+			"state":          state,
+			"queue_position": reservation.GetQueuePosition(),
+		})
+
+		select {
+		case <-ctx.Done():
+			err := fmt.Errorf("reservation %s did not become active before the create timeout, last state: %s",
+				reservation.GetId(), state)
+			diagnostics.AddError("Timeout waiting for reservation", err.Error())
+			return reservation, err
+		case <-ticker.C:
+		}
+
+		refreshed, _, err := GetReservation(ctx, client, diagnostics, reservation.GetId())
+		if err != nil {
+			return reservation, err
+		}
+		reservation = refreshed
+	}
+}
+
+// Cancel a reservation that is still waiting for hardware. The create context may already be done,
+// so the request gets its own short timeout.
+func cancelWaitingReservation(client *hwmux.APIClient, diagnostics *diag.Diagnostics, reservation *hwmux.ReservationSessionSerializerReadOnly) {
+	switch hwmux.StateEnum(reservationState(reservation)) {
+	case hwmux.STATEENUM_CRE_PEND, hwmux.STATEENUM_QUE, hwmux.STATEENUM_RES_PEND:
+	default:
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), reservationCancelTimeout)
+	defer cancel()
+
+	_, httpRes, err := client.ReservationsApi.ReservationsCancelUpdate(ctx, reservation.GetId()).Execute()
+	if err != nil {
+		diagnostics.AddWarning(
+			"Unable to cancel reservation "+reservation.GetId(),
+			"The reservation did not become active and could not be cancelled: "+err.Error()+"\n"+ResponseBodyToString(httpRes),
+		)
+	}
+}
+
+// Returns true when the lease of the reservation is removed, which hwmux cannot do in place
+func leaseRemoved(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !req.StateValue.IsNull() && req.PlanValue.IsNull()
+}
+
+// Get the state of a reservation, or an empty string if hwmux did not set it
+func reservationState(reservation *hwmux.ReservationSessionSerializerReadOnly) string {
+	state, ok := reservation.GetStateOk()
+	if !ok || state == nil || state.StateEnum == nil {
+		return ""
+	}
+	return string(*state.StateEnum)
+}
+
+// Reservations in these states do not hold any hardware anymore
+func isReservationEnded(state string) bool {
+	switch hwmux.StateEnum(state) {
+	case hwmux.STATEENUM_FIN, hwmux.STATEENUM_EXP, hwmux.STATEENUM_FAIL:
+		return true
+	}
+	return false
+}
+
+// Convert IDs returned by hwmux to terraform values. Returns nil for an empty list when nilIfEmpty is set,
+// so that unset optional attributes stay unset.
+func int32sToInt64Values(ids []int32, nilIfEmpty bool) []types.Int64 {
+	if nilIfEmpty && len(ids) == 0 {
+		return nil
+	}
+	values := make([]types.Int64, len(ids))
+	for i, id := range ids {
+		values[i] = types.Int64Value(int64(id))
+	}
+	return values
+}
@@ -0,0 +1,295 @@
+package hwmux
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccReservationResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "hwmux_device_group" "test" {
+	name              = "test_reservation"
+	devices           = [1]
+	permission_groups = ["All users"]
+}
+
+resource "hwmux_reservation" "test" {
+	device_groups    = [hwmux_device_group.test.id]
+	details          = "Terraform acceptance test"
+	lease_duration_s = 3600
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("hwmux_reservation.test", "id"),
+					resource.TestCheckResourceAttr("hwmux_reservation.test", "state", "ACT"),
+					resource.TestCheckResourceAttr("hwmux_reservation.test", "details", "Terraform acceptance test"),
+					resource.TestCheckResourceAttr("hwmux_reservation.test", "device_groups.#", "1"),
+					resource.TestCheckResourceAttr("hwmux_reservation.test", "allocated_device_groups.#", "1"),
+					resource.TestCheckResourceAttrSet("hwmux_reservation.test", "lease_expires"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "hwmux_reservation.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"lease_duration_s"},
+			},
+			// Extending the lease keeps the reservation
+			{
+				Config: providerConfig + `
+resource "hwmux_device_group" "test" {
+	name              = "test_reservation"
+	devices           = [1]
+	permission_groups = ["All users"]
+}
+
+resource "hwmux_reservation" "test" {
+	device_groups    = [hwmux_device_group.test.id]
+	details          = "Terraform acceptance test"
+	lease_duration_s = 7200
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hwmux_reservation.test", "state", "ACT"),
+					resource.TestCheckResourceAttr("hwmux_reservation.test", "lease_duration_s", "7200"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// Build the JSON body of a reservation in the given state
+func fakeReservationBody(state string) string {
+	return fmt.Sprintf(`{"id": "abc", "state": %q, "details": "test", "response_message": "", "r_device_groups": [1], `+
+		`"a_device_groups": [1], "a_devices": [], "r_devices": []}`, state)
+}
+
+func TestWaitForReservationActive(t *testing.T) {
+	reservationPollInterval = time.Millisecond
+	t.Cleanup(func() { reservationPollInterval = 5 * time.Second })
+
+	states := []string{"RES_PEND", "ACT"}
+	requests := 0
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(fakeReservationBody(states[requests])))
+		requests++
+	})
+
+	var diagnostics diag.Diagnostics
+	queued := &hwmux.ReservationSessionSerializerReadOnly{Id: "abc"}
+	queued.SetState(hwmux.StateEnumAsReservationSessionSerializerReadOnlyState(hwmux.STATEENUM_QUE.Ptr()))
+	reservation, err := waitForReservationActive(context.Background(), client, &diagnostics, queued)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v %v", err, diagnostics)
+	}
+	if reservationState(reservation) != "ACT" {
+		t.Fatalf("expected the reservation to be active, got %q", reservationState(reservation))
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 polls, got %d", requests)
+	}
+}
+
+func TestWaitForReservationActiveFails(t *testing.T) {
+	reservationPollInterval = time.Millisecond
+	t.Cleanup(func() { reservationPollInterval = 5 * time.Second })
+
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(fakeReservationBody("FAIL")))
+	})
+
+	var diagnostics diag.Diagnostics
+	queued := &hwmux.ReservationSessionSerializerReadOnly{Id: "abc"}
+	queued.SetState(hwmux.StateEnumAsReservationSessionSerializerReadOnlyState(hwmux.STATEENUM_QUE.Ptr()))
+	_, err := waitForReservationActive(context.Background(), client, &diagnostics, queued)
+
+	if err == nil || !diagnostics.HasError() {
+		t.Fatalf("expected an error for a failed reservation")
+	}
+}
+
+func TestWaitForReservationActiveTimeout(t *testing.T) {
+	reservationPollInterval = time.Millisecond
+	t.Cleanup(func() { reservationPollInterval = 5 * time.Second })
+
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(fakeReservationBody("QUE")))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	var diagnostics diag.Diagnostics
+	queued := &hwmux.ReservationSessionSerializerReadOnly{Id: "abc"}
+	queued.SetState(hwmux.StateEnumAsReservationSessionSerializerReadOnlyState(hwmux.STATEENUM_QUE.Ptr()))
+	_, err := waitForReservationActive(ctx, client, &diagnostics, queued)
+
+	if err == nil || !diagnostics.HasError() {
+		t.Fatalf("expected an error when the reservation does not become active in time")
+	}
+}
+
+func TestCancelWaitingReservation(t *testing.T) {
+	cases := map[string]struct {
+		state     hwmux.StateEnum
+		cancelled bool
+	}{
+		"queued":  {hwmux.STATEENUM_QUE, true},
+		"pending": {hwmux.STATEENUM_RES_PEND, true},
+		"failed":  {hwmux.STATEENUM_FAIL, false},
+		"active":  {hwmux.STATEENUM_ACT, false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cancelled := false
+			client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+				cancelled = true
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(fakeReservationBody("FIN")))
+			})
+
+			var diagnostics diag.Diagnostics
+			reservation := &hwmux.ReservationSessionSerializerReadOnly{Id: "abc"}
+			reservation.SetState(hwmux.StateEnumAsReservationSessionSerializerReadOnlyState(tc.state.Ptr()))
+			cancelWaitingReservation(client, &diagnostics, reservation)
+
+			if diagnostics.HasError() || diagnostics.WarningsCount() != 0 {
+				t.Fatalf("unexpected diagnostics: %v", diagnostics)
+			}
+			if cancelled != tc.cancelled {
+				t.Errorf("expected cancelled to be %t, got %t", tc.cancelled, cancelled)
+			}
+		})
+	}
+}
+
+func TestCancelWaitingReservationTimeout(t *testing.T) {
+	reservationCancelTimeout = 20 * time.Millisecond
+	t.Cleanup(func() { reservationCancelTimeout = 30 * time.Second })
+
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		<-req.Context().Done()
+	})
+
+	var diagnostics diag.Diagnostics
+	reservation := &hwmux.ReservationSessionSerializerReadOnly{Id: "abc"}
+	reservation.SetState(hwmux.StateEnumAsReservationSessionSerializerReadOnlyState(hwmux.STATEENUM_QUE.Ptr()))
+	cancelWaitingReservation(client, &diagnostics, reservation)
+
+	if diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a warning when the reservation cannot be cancelled in time, got %v", diagnostics)
+	}
+}
+
+func TestReservationUpdateExtendsLease(t *testing.T) {
+	ctx := context.Background()
+	extended := false
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut || req.URL.Path != "/api/reservations/abc/extend/" {
+			t.Errorf("the reservation must be extended in place, got %s %s", req.Method, req.URL)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(req.Body).Decode(&body)
+		if body["extension_duration_s"] != float64(600) || body["extend_existing"] != false {
+			t.Errorf("expected the lease to restart for 600 seconds, got %v", body)
+		}
+		extended = true
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(fakeReservationBody("ACT")))
+	})
+
+	r := NewReservationResource()
+	r.(tfresource.ResourceWithConfigure).Configure(ctx, tfresource.ConfigureRequest{ProviderData: client}, &tfresource.ConfigureResponse{})
+
+	schemaResp := &tfresource.SchemaResponse{}
+	r.Schema(ctx, tfresource.SchemaRequest{}, schemaResp)
+	timeoutsType := schemaResp.Schema.Blocks["timeouts"].Type().(timeouts.Type)
+	model := &ReservationResourceModel{
+		ID:                    types.StringValue("abc"),
+		DeviceGroups:          []types.Int64{types.Int64Value(1)},
+		Details:               types.StringValue("test"),
+		LeaseDurationS:        types.Int64Value(300),
+		State:                 types.StringValue("ACT"),
+		LeaseExpires:          types.StringValue(""),
+		AllocatedDevices:      []types.Int64{},
+		AllocatedDeviceGroups: []types.Int64{types.Int64Value(1)},
+		Timeouts:              timeouts.Value{Object: types.ObjectNull(timeoutsType.AttrTypes)},
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	diags := state.Set(ctx, model)
+
+	model.LeaseDurationS = types.Int64Value(600)
+	model.State = types.StringUnknown()
+	model.LeaseExpires = types.StringUnknown()
+	model.AllocatedDevices = nil
+	model.AllocatedDeviceGroups = nil
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	diags.Append(plan.Set(ctx, model)...)
+	if diags.HasError() {
+		t.Fatalf("unable to build the plan: %v", diags)
+	}
+
+	resp := &tfresource.UpdateResponse{State: state}
+	r.Update(ctx, tfresource.UpdateRequest{Plan: plan, State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if !extended {
+		t.Fatalf("expected the lease to be extended")
+	}
+
+	var data ReservationResourceModel
+	resp.State.Get(ctx, &data)
+	if data.LeaseDurationS.ValueInt64() != 600 || data.State.ValueString() != "ACT" {
+		t.Errorf("expected the active reservation with the new lease, got %s %s", data.LeaseDurationS, data.State)
+	}
+}
+
+func TestReservationLeaseRemoved(t *testing.T) {
+	cases := map[string]struct {
+		state    types.Int64
+		plan     types.Int64
+		expected bool
+	}{
+		"changed": {types.Int64Value(300), types.Int64Value(600), false},
+		"added":   {types.Int64Null(), types.Int64Value(600), false},
+		"removed": {types.Int64Value(300), types.Int64Null(), true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resp := &int64planmodifier.RequiresReplaceIfFuncResponse{}
+			leaseRemoved(context.Background(), planmodifier.Int64Request{StateValue: tc.state, PlanValue: tc.plan}, resp)
+			if resp.RequiresReplace != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, resp.RequiresReplace)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package hwmux
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultRetryWaitMin = 1 * time.Second
+	defaultRetryWaitMax = 30 * time.Second
+)
+
+// retryTransport wraps an http.RoundTripper and retries idempotent requests
+// that failed because of a transient hwmux or network error.
+type retryTransport struct {
+	next         http.RoundTripper
+	maxRetries   int
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+}
+
+// Create a new http.Client that retries transient failures
+func newRetryingHTTPClient(maxRetries int, retryWaitMin time.Duration, retryWaitMax time.Duration) *http.Client {
+	return &http.Client{
+		Transport: &retryTransport{
+			next:         http.DefaultTransport,
+			maxRetries:   maxRetries,
+			retryWaitMin: retryWaitMin,
+			retryWaitMax: retryWaitMax,
+		},
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+
+		if attempt >= t.maxRetries || !isIdempotent(req.Method) || !shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+
+		logFields := map[string]interface{}{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		}
+		if err != nil {
+			logFields["error"] = err.Error()
+		} else {
+			logFields["status"] = resp.StatusCode
+		}
+		tflog.Warn(ctx, "Retrying hwmux API request", logFields)
+
+		// drain the body so that the connection can be reused
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Compute how long to wait before the next attempt. The Retry-After header takes precedence
+// over the exponential backoff. Both are capped at retryWaitMax.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > t.retryWaitMax {
+				return t.retryWaitMax
+			}
+			return wait
+		}
+	}
+
+	// compare as a float so that large attempts can't overflow the duration
+	wait := math.Pow(2, float64(attempt)) * float64(t.retryWaitMin)
+	if wait > float64(t.retryWaitMax) {
+		return t.retryWaitMax
+	}
+	return time.Duration(wait)
+}
+
+// Parse a Retry-After header, which is either a number of seconds or an HTTP date
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// Only requests that can safely be sent twice are retried
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// Connection errors, rate limiting and gateway errors are considered transient
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package hwmux
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Start a server answering with the given statuses in order, then 200. Returns the server and the request counter.
+func newFlakyServer(t *testing.T, statuses []int, header http.Header) (*httptest.Server, *int) {
+	count := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		if req.Method == http.MethodPut && string(body) != `{"name":"test"}` {
+			t.Errorf("unexpected request body %q", string(body))
+		}
+		count++
+		if count <= len(statuses) {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(statuses[count-1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &count
+}
+
+func TestRetryTransportRetriesTransientErrors(t *testing.T) {
+	server, count := newFlakyServer(t, []int{http.StatusServiceUnavailable, http.StatusBadGateway}, nil)
+	client := newRetryingHTTPClient(3, time.Millisecond, 10*time.Millisecond)
+
+	req, _ := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{"name":"test"}`))
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if *count != 3 {
+		t.Fatalf("expected 3 requests, got %d", *count)
+	}
+}
+
+func TestRetryTransportStopsAfterMaxRetries(t *testing.T) {
+	statuses := []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests}
+	server, count := newFlakyServer(t, statuses, nil)
+	client := newRetryingHTTPClient(2, time.Millisecond, 10*time.Millisecond)
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", resp.StatusCode)
+	}
+	if *count != 3 {
+		t.Fatalf("expected 3 requests, got %d", *count)
+	}
+}
+
+func TestRetryTransportDoesNotRetryPost(t *testing.T) {
+	server, count := newFlakyServer(t, []int{http.StatusServiceUnavailable}, nil)
+	client := newRetryingHTTPClient(3, time.Millisecond, 10*time.Millisecond)
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503, got %d", resp.StatusCode)
+	}
+	if *count != 1 {
+		t.Fatalf("expected 1 request, got %d", *count)
+	}
+}
+
+func TestRetryTransportDoesNotRetryClientErrors(t *testing.T) {
+	server, count := newFlakyServer(t, []int{http.StatusBadRequest}, nil)
+	client := newRetryingHTTPClient(3, time.Millisecond, 10*time.Millisecond)
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", resp.StatusCode)
+	}
+	if *count != 1 {
+		t.Fatalf("expected 1 request, got %d", *count)
+	}
+}
+
+func TestRetryTransportHonorsRetryAfter(t *testing.T) {
+	header := http.Header{"Retry-After": []string{"1"}}
+	server, count := newFlakyServer(t, []int{http.StatusServiceUnavailable}, header)
+	client := newRetryingHTTPClient(1, time.Millisecond, 2*time.Second)
+
+	start := time.Now()
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if *count != 2 {
+		t.Fatalf("expected 2 requests, got %d", *count)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected to wait for the Retry-After delay, waited %s", elapsed)
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	transport := &retryTransport{retryWaitMin: time.Second, retryWaitMax: 5 * time.Second}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for attempt, want := range expected {
+		if got := transport.backoff(attempt, nil); got != want {
+			t.Errorf("attempt %d: expected %s, got %s", attempt, want, got)
+		}
+	}
+}
+
+func TestRetryTransportBackoffZeroMin(t *testing.T) {
+	transport := &retryTransport{retryWaitMin: 0, retryWaitMax: 5 * time.Second}
+
+	for attempt := 0; attempt < 3; attempt++ {
+		if got := transport.backoff(attempt, nil); got != 0 {
+			t.Errorf("attempt %d: expected no wait, got %s", attempt, got)
+		}
+	}
+}
+
+func TestRetryTransportBackoffCapsRetryAfter(t *testing.T) {
+	transport := &retryTransport{retryWaitMin: time.Second, retryWaitMax: 5 * time.Second}
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3600"}}}
+
+	if got := transport.backoff(0, resp); got != 5*time.Second {
+		t.Fatalf("expected the Retry-After delay to be capped at 5s, got %s", got)
+	}
+}
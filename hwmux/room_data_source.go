@@ -7,6 +7,7 @@ import (
 	"github.com/Silabs-UTF/hwmux-client-golang/v2"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -89,17 +90,12 @@ func (d *RoomDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 	// Map response body to model
-	room, _, err := GetRoom(d.client, &resp.Diagnostics, data.Name.ValueString())
+	room, _, err := GetRoom(ctx, d.client, &resp.Diagnostics, data.Name.ValueString())
 	if err != nil {
 		return
 	}
 
-	// Map response body to model
-	data.ID = types.StringValue(room.GetName())
-	data.Name = types.StringValue(room.GetName())
-	data.Site = types.StringValue(room.GetSite())
-
-	err = MarshalMetadataSetError(room.GetMetadata(), &resp.Diagnostics, "room", &data.Metadata)
+	err = updateRoomDataSourceModelFromResponse(room, &data, &resp.Diagnostics)
 	if err != nil {
 		return
 	}
@@ -107,3 +103,12 @@ func (d *RoomDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// Map response body to the data source model
+func updateRoomDataSourceModelFromResponse(room *hwmux.Room, data *RoomDataSourceModel, diagnostics *diag.Diagnostics) error {
+	data.ID = types.StringValue(room.GetName())
+	data.Name = types.StringValue(room.GetName())
+	data.Site = types.StringValue(room.GetSite())
+
+	return MarshalMetadataSetError(room.GetMetadata(), diagnostics, "room", &data.Metadata)
+}
@@ -0,0 +1,318 @@
+package hwmux
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &RoomResource{}
+var _ resource.ResourceWithImportState = &RoomResource{}
+
+func NewRoomResource() resource.Resource {
+	return &RoomResource{}
+}
+
+// RoomResource defines the resource implementation.
+type RoomResource struct {
+	client *hwmux.APIClient
+}
+
+// RoomResourceModel describes the resource data model.
+type RoomResourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Site        types.String   `tfsdk:"site"`
+	Description types.String   `tfsdk:"description"`
+	Metadata    types.String   `tfsdk:"metadata"`
+	LastUpdated types.String   `tfsdk:"last_updated"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *RoomResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room"
+}
+
+func (r *RoomResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Room resource",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Room identifier. Always equals the name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Room name. Must be unique. Changing it forces the creation of a new room.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.LengthAtMost(100),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"site": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the site the room is in. Changing it forces the creation of a new room.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Room description.",
+			},
+			"metadata": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Room metadata.",
+				PlanModifiers: []planmodifier.String{
+					metadataSemanticEquality{},
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Description: "Timestamp of the last Terraform update of the resource.",
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *RoomResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hwmux.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *hwmux.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RoomResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *RoomResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "create", defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	room, err := createRoomFromPlan(data, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create room API request based on plan", err.Error(),
+		)
+		return
+	}
+
+	// create new room
+	room, httpRes, err := r.client.SitesApi.SitesRoomsCreate(ctx, data.Site.ValueString()).Room(*room).Execute()
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error creating room %s", data.Name.String()),
+			fmt.Sprintf("Could not create room %s, unexpected error: %s\n%s", data.Name.String(), err.Error(), ResponseBodyToString(httpRes)),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	err = updateRoomModelFromResponse(room, data, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Updating the room model failed", err.Error(),
+		)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *RoomResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "read", defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	// Get refreshed room value from hwmux
+	var readDiagnostics diag.Diagnostics
+	room, httpRes, err := GetRoom(ctx, r.client, &readDiagnostics, data.ID.ValueString())
+	if removeResourceIfNotFound(ctx, httpRes, readDiagnostics, resp, "Room") {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error reading room %s", data.ID.String()),
+			fmt.Sprintf("Could not read room %s, unexpected error: %s", data.ID.String(), err.Error()),
+		)
+		return
+	}
+
+	// Map response body to model
+	data.ID = types.StringValue(room.GetName())
+	data.Name = types.StringValue(room.GetName())
+	data.Site = types.StringValue(room.GetSite())
+	data.Description = types.StringValue(room.GetDescription())
+
+	err = MarshalMetadataSetError(room.GetMetadata(), &resp.Diagnostics, "room", &data.Metadata)
+	if err != nil {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *RoomResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "update", defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	room, err := createRoomFromPlan(data, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create room API request based on plan", err.Error(),
+		)
+		return
+	}
+
+	// update room
+	room, httpRes, err := r.client.SitesApi.SitesRoomsUpdate(ctx, data.ID.ValueString(), data.Site.ValueString()).Room(*room).Execute()
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating room "+data.ID.String(),
+			fmt.Sprintf("Could not update room %s, unexpected error: %s\n%s", data.ID.String(), err.Error(), ResponseBodyToString(httpRes)),
+		)
+		return
+	}
+
+	// set model based on response
+	err = updateRoomModelFromResponse(room, data, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Updating the room model failed", err.Error(),
+		)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *RoomResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "delete", defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	// Delete existing
+	httpRes, err := r.client.SitesApi.SitesRoomsDestroy(ctx, data.ID.ValueString(), data.Site.ValueString()).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting room "+data.ID.String(),
+			fmt.Sprintf("Could not delete room %s, unexpected error: %s\n%s", data.ID.String(), err.Error(), ResponseBodyToString(httpRes)),
+		)
+		return
+	}
+}
+
+func (r *RoomResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Create a Room based on a terraform plan
+func createRoomFromPlan(plan *RoomResourceModel, diagnostics *diag.Diagnostics) (*hwmux.Room, error) {
+	room := hwmux.NewRoom(plan.Name.ValueString(), plan.Site.ValueString())
+
+	if !plan.Description.IsUnknown() {
+		room.SetDescription(plan.Description.ValueString())
+	}
+
+	if !plan.Metadata.IsUnknown() {
+		metadata, errorMet := UnmarshalMetadataSetError(plan.Metadata.ValueString(), diagnostics, "room")
+		if errorMet != nil {
+			return nil, errorMet
+		}
+		room.SetMetadata(*metadata)
+	}
+
+	return room, nil
+}
+
+// Map response body to model and populate Computed attribute values
+func updateRoomModelFromResponse(room *hwmux.Room, plan *RoomResourceModel, diagnostics *diag.Diagnostics) (err error) {
+	plan.ID = types.StringValue(room.GetName())
+	plan.Name = types.StringValue(room.GetName())
+	plan.Site = types.StringValue(room.GetSite())
+	plan.Description = types.StringValue(room.GetDescription())
+
+	err = MarshalMetadataSetError(room.GetMetadata(), diagnostics, "room", &plan.Metadata)
+	if err != nil {
+		return
+	}
+
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	return nil
+}
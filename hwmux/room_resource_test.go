@@ -0,0 +1,70 @@
+package hwmux
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccRoomResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "hwmux_room" "test" {
+	name = "test_room_tf"
+	site = "Site_0"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hwmux_room.test", "id", "test_room_tf"),
+					resource.TestCheckResourceAttr("hwmux_room.test", "name", "test_room_tf"),
+					resource.TestCheckResourceAttr("hwmux_room.test", "site", "Site_0"),
+					// Verify the room item has Computed attributes filled.
+					resource.TestCheckResourceAttr("hwmux_room.test", "metadata", "{}"),
+					resource.TestCheckResourceAttrSet("hwmux_room.test", "last_updated"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "hwmux_room.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The last_updated attribute does not exist in the hwmux
+				// API, therefore there is no value for it during import.
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "hwmux_room" "test" {
+	name        = "test_room_tf"
+	site        = "Site_0"
+	description = "Lab room"
+	metadata    = "{ \"floor\": 2 }"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hwmux_room.test", "name", "test_room_tf"),
+					resource.TestCheckResourceAttr("hwmux_room.test", "description", "Lab room"),
+					resource.TestCheckResourceAttr("hwmux_room.test", "metadata", `{ "floor": 2 }`),
+				),
+			},
+			// Reformatting the metadata json does not plan an update
+			{
+				Config: providerConfig + `
+resource "hwmux_room" "test" {
+	name        = "test_room_tf"
+	site        = "Site_0"
+	description = "Lab room"
+	metadata    = "{\"floor\":2}"
+}
+`,
+				PlanOnly: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
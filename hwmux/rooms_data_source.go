@@ -0,0 +1,160 @@
+package hwmux
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &RoomsDataSource{}
+
+func NewRoomsDataSource() datasource.DataSource {
+	return &RoomsDataSource{}
+}
+
+type RoomsDataSource struct {
+	client *hwmux.APIClient
+}
+
+// roomsDataSourceModel maps the data source schema data.
+type RoomsDataSourceModel struct {
+	ID             types.String            `tfsdk:"id"`
+	Site           types.String            `tfsdk:"site"`
+	Name_regex     types.String            `tfsdk:"name_regex"`
+	MetadataFilter map[string]types.String `tfsdk:"metadata"`
+	Rooms          []RoomDataSourceModel   `tfsdk:"rooms"`
+}
+
+func (d *RoomsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rooms"
+}
+
+func (d *RoomsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Rooms data source. Lists all the rooms matching the given filters.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder identifier. Set to satisfy terraform restrictions.",
+				Computed:            true,
+			},
+			"site": schema.StringAttribute{
+				MarkdownDescription: "Only return the rooms located in this site.",
+				Optional:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only return the rooms whose name matches this regular expression.",
+				Optional:            true,
+			},
+			"metadata": schema.MapAttribute{
+				MarkdownDescription: "Only return the rooms whose metadata contains all of these key/value pairs. " +
+					"Values that are not strings in the metadata are compared using their json encoding.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"rooms": schema.ListNestedAttribute{
+				MarkdownDescription: "The rooms matching the filters.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Room identifier. Always equals the name.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Room name.",
+							Computed:            true,
+						},
+						"site": schema.StringAttribute{
+							MarkdownDescription: "Site.",
+							Computed:            true,
+						},
+						"metadata": schema.StringAttribute{
+							MarkdownDescription: "The metadata of the Room.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RoomsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hwmux.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *hwmux.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RoomsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RoomsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, err := regexpFilterFromConfig(data.Name_regex, "name_regex", &resp.Diagnostics)
+	if err != nil {
+		return
+	}
+
+	// the filters supported by the API are applied server side
+	request := d.client.RoomsApi.RoomsList(ctx)
+	if !data.Site.IsNull() {
+		request = request.Site(data.Site.ValueString())
+	}
+
+	rooms, err := ListRooms(&resp.Diagnostics, request)
+	if err != nil {
+		return
+	}
+
+	metadataFilter := metadataFilterFromConfig(data.MetadataFilter)
+
+	// the remaining filters are applied client side
+	data.Rooms = []RoomDataSourceModel{}
+	for i := range rooms {
+		room := &rooms[i]
+		if nameRegex != nil && !nameRegex.MatchString(room.GetName()) {
+			continue
+		}
+		if !MetadataMatches(room.GetMetadata(), metadataFilter) {
+			continue
+		}
+
+		var roomModel RoomDataSourceModel
+		err = updateRoomDataSourceModelFromResponse(room, &roomModel, &resp.Diagnostics)
+		if err != nil {
+			return
+		}
+		data.Rooms = append(data.Rooms, roomModel)
+	}
+
+	data.ID = types.StringValue("rooms")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,31 @@
+package hwmux
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccRoomsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "hwmux_rooms" "test" {
+	site       = "Site_0"
+	name_regex = "^Room_0$"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hwmux_rooms.test", "rooms.#", "1"),
+					resource.TestCheckResourceAttr("data.hwmux_rooms.test", "rooms.0.id", "Room_0"),
+					resource.TestCheckResourceAttr("data.hwmux_rooms.test", "rooms.0.name", "Room_0"),
+					resource.TestCheckResourceAttr("data.hwmux_rooms.test", "rooms.0.site", "Site_0"),
+					resource.TestCheckResourceAttrSet("data.hwmux_rooms.test", "rooms.0.metadata"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,51 @@
+package hwmux
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+const (
+	defaultCreateTimeout = 20 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 20 * time.Minute
+)
+
+// Schema of the timeouts block shared by all resources
+func timeoutsBlock(ctx context.Context) schema.Block {
+	block := timeouts.Block(ctx, timeouts.Opts{
+		Create: true,
+		Read:   true,
+		Update: true,
+		Delete: true,
+	}).(schema.SingleNestedBlock)
+	block.MarkdownDescription = "Timeouts for the operations on the resource."
+	return block
+}
+
+// Get the timeout of the operation from the timeouts block, falling back to the default when it is not set
+func getTimeout(ctx context.Context, value timeouts.Value, operation string, defaultTimeout time.Duration) (time.Duration, diag.Diagnostics) {
+	switch operation {
+	case "create":
+		return value.Create(ctx, defaultTimeout)
+	case "read":
+		return value.Read(ctx, defaultTimeout)
+	case "update":
+		return value.Update(ctx, defaultTimeout)
+	default:
+		return value.Delete(ctx, defaultTimeout)
+	}
+}
+
+// Derive a context that expires after the timeout of the operation
+func contextWithTimeout(ctx context.Context, value timeouts.Value, operation string, defaultTimeout time.Duration,
+	diagnostics *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	timeout, diags := getTimeout(ctx, value, operation, defaultTimeout)
+	diagnostics.Append(diags...)
+	return context.WithTimeout(ctx, timeout)
+}
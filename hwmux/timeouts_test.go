@@ -0,0 +1,54 @@
+package hwmux
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newTimeoutsObject(t *testing.T, values map[string]attr.Value) timeouts.Value {
+	attributeTypes := map[string]attr.Type{}
+	for _, operation := range []string{"create", "read", "update", "delete"} {
+		attributeTypes[operation] = types.StringType
+		if _, ok := values[operation]; !ok {
+			values[operation] = types.StringNull()
+		}
+	}
+	object, diags := types.ObjectValue(attributeTypes, values)
+	if diags.HasError() {
+		t.Fatalf("unable to build timeouts object: %v", diags)
+	}
+	return timeouts.Value{Object: object}
+}
+
+func TestGetTimeout(t *testing.T) {
+	ctx := context.Background()
+	value := newTimeoutsObject(t, map[string]attr.Value{
+		"create": types.StringValue("45m"),
+		"delete": types.StringValue("not a duration"),
+	})
+
+	timeout, diags := getTimeout(ctx, value, "create", defaultCreateTimeout)
+	if diags.HasError() || timeout != 45*time.Minute {
+		t.Errorf("expected 45m create timeout, got %s (%v)", timeout, diags)
+	}
+
+	timeout, diags = getTimeout(ctx, value, "update", defaultUpdateTimeout)
+	if diags.HasError() || timeout != defaultUpdateTimeout {
+		t.Errorf("expected default update timeout, got %s (%v)", timeout, diags)
+	}
+
+	_, diags = getTimeout(ctx, value, "delete", defaultDeleteTimeout)
+	if !diags.HasError() {
+		t.Errorf("expected an error for an invalid delete timeout")
+	}
+
+	timeout, diags = getTimeout(ctx, timeouts.Value{Object: types.ObjectNull(value.AttributeTypes(ctx))}, "read", defaultReadTimeout)
+	if diags.HasError() || timeout != defaultReadTimeout {
+		t.Errorf("expected default read timeout without a timeouts block, got %s (%v)", timeout, diags)
+	}
+}
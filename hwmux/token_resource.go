@@ -3,19 +3,28 @@ package hwmux
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// Positive durations accepted by time.ParseDuration
+var durationRegex = regexp.MustCompile(`^(([0-9]+(\.[0-9]*)?|\.[0-9]+)(ns|us|µs|ms|s|m|h))+$`)
+
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &TokenResource{}
+var _ resource.ResourceWithModifyPlan = &TokenResource{}
 
 func NewTokenResource() resource.Resource {
 	return &TokenResource{}
@@ -28,11 +37,13 @@ type TokenResource struct {
 
 // TokenResourceModel describes the resource data model.
 type TokenResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Token       types.String `tfsdk:"token"`
-	UserId      types.String `tfsdk:"user_id"`
-	DateCreated types.String `tfsdk:"date_created"`
-	LastUpdated types.String `tfsdk:"last_updated"`
+	ID               types.String `tfsdk:"id"`
+	Token            types.String `tfsdk:"token"`
+	UserId           types.String `tfsdk:"user_id"`
+	RotationTriggers types.Map    `tfsdk:"rotation_triggers"`
+	RotateAfter      types.String `tfsdk:"rotate_after"`
+	DateCreated      types.String `tfsdk:"date_created"`
+	LastUpdated      types.String `tfsdk:"last_updated"`
 }
 
 func (r *TokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -42,21 +53,42 @@ func (r *TokenResource) Metadata(ctx context.Context, req resource.MetadataReque
 func (r *TokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Token resource",
+		MarkdownDescription: "Token resource. hwmux holds a single token per user and has no endpoint to delete it, " +
+			"so destroying the resource revokes the token by creating a new token for the user and discarding it. " +
+			"Creating a token replaces the previous token of the user, which stops working for any other consumer. " +
+			"The token is replaced when `user_id` or `rotation_triggers` change, when it is older than `rotate_after`, " +
+			"and when it was replaced outside of Terraform.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "Token identifier.",
+				MarkdownDescription: "Token identifier. Always equals the user_id.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"user_id": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "The user Id.",
+				MarkdownDescription: "The user Id. Changing it forces the creation of a new token.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotation_triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that force the rotation of the token when they change.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotate_after": schema.StringAttribute{
+				MarkdownDescription: "The age after which the token is rotated on the next apply. A string that can be " +
+					"[parsed as a duration](https://pkg.go.dev/time#ParseDuration) consisting of numbers and unit suffixes, " +
+					"such as \"720h\" or \"2h45m\".",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(durationRegex, "must be a duration, such as \"720h\" or \"2h45m\""),
 				},
 			},
 			"token": schema.StringAttribute{
@@ -67,6 +99,9 @@ func (r *TokenResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"date_created": schema.StringAttribute{
 				Description: "Timestamp of the time the token was created.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"last_updated": schema.StringAttribute{
 				Description: "Timestamp of the last Terraform update of the resource.",
@@ -107,19 +142,18 @@ func (r *TokenResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// create new token
-	tokenSerializer, httpRes, err := r.client.UserApi.UserTokenCreate(context.Background(), data.UserId.ValueString()).Execute()
-	data.ID = types.StringValue(tokenSerializer.GetKey())
-
+	tokenSerializer, httpRes, err := r.client.UserApi.UserTokenCreate(ctx, data.UserId.ValueString()).Execute()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating token",
-			"Could not create token, unexpected error: "+err.Error()+"\n"+BodyToString(&httpRes.Body),
+			"Could not create token, unexpected error: "+err.Error()+"\n"+ResponseBodyToString(httpRes),
 		)
 		return
 	}
 
 	// Map response body to schema and populate Computed attribute values
 	// set model based on response
+	data.ID = data.UserId
 	err = updateTokenModelFromResponse(tokenSerializer, data, &resp.Diagnostics, r.client)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -143,52 +177,49 @@ func (r *TokenResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Get refreshed token value from hwmux
-	token, _, err := GetToken(r.client, &resp.Diagnostics, data.UserId.ValueString())
+	var readDiagnostics diag.Diagnostics
+	token, httpRes, err := GetToken(ctx, r.client, &readDiagnostics, data.UserId.ValueString())
+	if removeResourceIfNotFound(ctx, httpRes, readDiagnostics, resp, "Token") {
+		return
+	}
 	if err != nil {
 		return
 	}
 
-	err = updateTokenModelFromResponse(token, data, &resp.Diagnostics, r.client)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Updating the token model failed", err.Error(),
+	// the stored token does not work anymore if it was replaced outside of Terraform, create a new one
+	if token.GetKey() != data.Token.ValueString() {
+		resp.Diagnostics.AddWarning(
+			"Token replaced outside of Terraform",
+			fmt.Sprintf("The token of user %s in hwmux does not match the token in the state, a new token will be created.", data.UserId.ValueString()),
 		)
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
+	// states written by older versions of the provider used the token as identifier
+	data.ID = data.UserId
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *TokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data *TokenResourceModel
+	var state *TokenResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// update token
-	tokenSerializer, httpRes, err := r.client.UserApi.UserTokenCreate(context.Background(), data.UserId.ValueString()).Execute()
-
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating token "+data.ID.String(),
-			"Could not update token, unexpected error: "+err.Error()+"\n"+BodyToString(&httpRes.Body),
-		)
-		return
-	}
-
-	// set model based on response
-	err = updateTokenModelFromResponse(tokenSerializer, data, &resp.Diagnostics, r.client)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Updating the token model failed", err.Error(),
-		)
-		return
-	}
+	// only rotate_after can be updated in place, the token is rotated by replacing the resource
+	data.ID = state.ID
+	data.Token = state.Token
+	data.DateCreated = state.DateCreated
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -204,17 +235,75 @@ func (r *TokenResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	// Delete existing (we delete by creating a new one that invalidates the previous one)
-	_, httpRes, err := r.client.UserApi.UserTokenCreate(context.Background(), data.UserId.ValueString()).Execute()
+	var deleteDiagnostics diag.Diagnostics
+	token, httpRes, err := GetToken(ctx, r.client, &deleteDiagnostics, data.UserId.ValueString())
+	// nothing to revoke if the user was deleted
+	if IsNotFound(httpRes) {
+		return
+	}
+	resp.Diagnostics.Append(deleteDiagnostics...)
+	if err != nil {
+		return
+	}
+
+	// the token was already replaced, for instance by the new token of a create_before_destroy replacement
+	if token.GetKey() != data.Token.ValueString() {
+		return
+	}
+
+	// Revoke the token (hwmux cannot delete tokens, so we create a new one that invalidates the previous one)
+	_, httpRes, err = r.client.UserApi.UserTokenCreate(ctx, data.UserId.ValueString()).Execute()
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error Deleting Token",
-			"Could not delete token, unexpected error: "+BodyToString(&httpRes.Body),
+			"Error Revoking Token",
+			"Could not revoke token, unexpected error: "+err.Error()+"\n"+ResponseBodyToString(httpRes),
 		)
 		return
 	}
 }
 
+// Plan the replacement of tokens that are older than rotate_after
+func (r *TokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to rotate on creation and destruction
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan *TokenResourceModel
+	var state *TokenResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !tokenRotationDue(state.DateCreated, plan.RotateAfter, time.Now()) {
+		return
+	}
+
+	// the replacement is only planned when the value of the attribute changes
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("date_created"), types.StringUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("date_created"))
+}
+
+// Returns true if a token created at dateCreated is older than rotateAfter
+func tokenRotationDue(dateCreated types.String, rotateAfter types.String, now time.Time) bool {
+	if rotateAfter.IsNull() || rotateAfter.IsUnknown() || dateCreated.IsNull() || dateCreated.IsUnknown() {
+		return false
+	}
+
+	rotationPeriod, err := time.ParseDuration(rotateAfter.ValueString())
+	if err != nil {
+		return false
+	}
+	created, err := time.Parse(time.RFC850, dateCreated.ValueString())
+	if err != nil {
+		return false
+	}
+
+	return !now.Before(created.Add(rotationPeriod))
+}
+
 // Map response body to model and populate Computed attribute values
 func updateTokenModelFromResponse(token *hwmux.Token, plan *TokenResourceModel, diagnostics *diag.Diagnostics, client *hwmux.APIClient) (err error) {
 	// Map response body to schema and populate Computed attribute values
@@ -1,8 +1,16 @@
 package hwmux
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -20,7 +28,7 @@ resource "hwmux_token" "test" {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("hwmux_token.test", "user_id", "dev1"),
 					resource.TestCheckResourceAttrSet("hwmux_token.test", "token"),
-					resource.TestCheckResourceAttrSet("hwmux_token.test", "id"),
+					resource.TestCheckResourceAttr("hwmux_token.test", "id", "dev1"),
 					resource.TestCheckResourceAttrSet("hwmux_token.test", "date_created"),
 					resource.TestCheckResourceAttrSet("hwmux_token.test", "last_updated"),
 				),
@@ -35,12 +43,168 @@ resource "hwmux_token" "test" {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("hwmux_token.test", "user_id", "dev2"),
 					resource.TestCheckResourceAttrSet("hwmux_token.test", "token"),
-					resource.TestCheckResourceAttrSet("hwmux_token.test", "id"),
+					resource.TestCheckResourceAttr("hwmux_token.test", "id", "dev2"),
 					resource.TestCheckResourceAttrSet("hwmux_token.test", "date_created"),
 					resource.TestCheckResourceAttrSet("hwmux_token.test", "last_updated"),
 				),
 			},
+			// Rotation testing
+			{
+				Config: providerConfig + `
+resource "hwmux_token" "test" {
+	user_id           = "dev2"
+	rotate_after      = "720h"
+	rotation_triggers = {
+		version = "2"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hwmux_token.test", "user_id", "dev2"),
+					resource.TestCheckResourceAttr("hwmux_token.test", "rotate_after", "720h"),
+					resource.TestCheckResourceAttr("hwmux_token.test", "rotation_triggers.version", "2"),
+					resource.TestCheckResourceAttrSet("hwmux_token.test", "token"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestTokenRotationDue(t *testing.T) {
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	dateCreated := types.StringValue(created.Format(time.RFC850))
+
+	cases := map[string]struct {
+		rotateAfter types.String
+		now         time.Time
+		expected    bool
+	}{
+		"not configured": {types.StringNull(), created.Add(1000 * time.Hour), false},
+		"not due":        {types.StringValue("24h"), created.Add(23 * time.Hour), false},
+		"due":            {types.StringValue("24h"), created.Add(24 * time.Hour), true},
+		"invalid":        {types.StringValue("a day"), created.Add(1000 * time.Hour), false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if due := tokenRotationDue(dateCreated, tc.rotateAfter, tc.now); due != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, due)
+			}
+		})
+	}
+}
+
+func TestTokenReadRemovesReplacedToken(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"key": "new", "created": "2024-01-01T12:00:00Z"}`))
+	})
+
+	r := NewTokenResource()
+	r.(tfresource.ResourceWithConfigure).Configure(ctx, tfresource.ConfigureRequest{ProviderData: client}, &tfresource.ConfigureResponse{})
+
+	state := newFakeResourceState(t, r, map[string]string{"id": "old", "token": "old", "user_id": "dev1"})
+	resp := &tfresource.ReadResponse{State: state}
+	r.Read(ctx, tfresource.ReadRequest{State: state}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error diagnostics: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Fatalf("expected the replaced token to be removed from state")
+	}
+}
+
+func TestTokenDeleteRevokesToken(t *testing.T) {
+	ctx := context.Background()
+	key := "old"
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		// hwmux replaces the token of the user when a new one is created
+		if req.Method == http.MethodPost {
+			key = "new"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"key": %q, "created": "2024-01-01T12:00:00Z"}`, key)
+	})
+
+	r := NewTokenResource()
+	r.(tfresource.ResourceWithConfigure).Configure(ctx, tfresource.ConfigureRequest{ProviderData: client}, &tfresource.ConfigureResponse{})
+
+	state := newFakeResourceState(t, r, map[string]string{"id": "dev1", "token": "old", "user_id": "dev1"})
+	resp := &tfresource.DeleteResponse{State: state}
+	r.Delete(ctx, tfresource.DeleteRequest{State: state}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error diagnostics: %v", resp.Diagnostics)
+	}
+	var diagnostics diag.Diagnostics
+	token, _, err := GetToken(ctx, client, &diagnostics, "dev1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.GetKey() == "old" {
+		t.Errorf("expected the deleted token to be revoked")
+	}
+}
+
+func TestTokenDeleteKeepsReplacedToken(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			t.Errorf("the token replaced outside of the resource must not be revoked, got %s %s", req.Method, req.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"key": "new", "created": "2024-01-01T12:00:00Z"}`))
+	})
+
+	r := NewTokenResource()
+	r.(tfresource.ResourceWithConfigure).Configure(ctx, tfresource.ConfigureRequest{ProviderData: client}, &tfresource.ConfigureResponse{})
+
+	state := newFakeResourceState(t, r, map[string]string{"id": "dev1", "token": "old", "user_id": "dev1"})
+	resp := &tfresource.DeleteResponse{State: state}
+	r.Delete(ctx, tfresource.DeleteRequest{State: state}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error diagnostics: %v", resp.Diagnostics)
+	}
+}
+
+func TestTokenReadUsesUserIdAsID(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"key": "abc", "created": "2024-01-01T12:00:00Z"}`))
+	})
+
+	r := NewTokenResource()
+	r.(tfresource.ResourceWithConfigure).Configure(ctx, tfresource.ConfigureRequest{ProviderData: client}, &tfresource.ConfigureResponse{})
+
+	// the token was used as identifier before
+	state := newFakeResourceState(t, r, map[string]string{"id": "abc", "token": "abc", "user_id": "dev1"})
+	resp := &tfresource.ReadResponse{State: state}
+	r.Read(ctx, tfresource.ReadRequest{State: state}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error diagnostics: %v", resp.Diagnostics)
+	}
+	var id string
+	resp.State.GetAttribute(ctx, path.Root("id"), &id)
+	if id != "dev1" {
+		t.Errorf("expected the user_id to be used as identifier, got %s", id)
+	}
+}
+
+func TestDurationRegex(t *testing.T) {
+	for _, duration := range []string{"720h", "2h45m", "1.5h", "30s", "300ms"} {
+		if !durationRegex.MatchString(duration) {
+			t.Errorf("expected %s to be a valid duration", duration)
+		}
+	}
+	for _, duration := range []string{"", "720", "30 days", "-1h", "1d"} {
+		if durationRegex.MatchString(duration) {
+			t.Errorf("expected %s to be an invalid duration", duration)
+		}
+	}
+}
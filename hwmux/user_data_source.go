@@ -0,0 +1,159 @@
+package hwmux
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &UserDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &UserDataSource{}
+
+func NewUserDataSource() datasource.DataSource {
+	return &UserDataSource{}
+}
+
+type UserDataSource struct {
+	client *hwmux.APIClient
+}
+
+// userDataSourceModel maps the data source schema data.
+type UserDataSourceModel struct {
+	ID               types.Int64    `tfsdk:"id"`
+	Username         types.String   `tfsdk:"username"`
+	FirstName        types.String   `tfsdk:"first_name"`
+	LastName         types.String   `tfsdk:"last_name"`
+	Email            types.String   `tfsdk:"email"`
+	IsStaff          types.Bool     `tfsdk:"is_staff"`
+	IsSuperuser      types.Bool     `tfsdk:"is_superuser"`
+	PermissionGroups []types.String `tfsdk:"permission_groups"`
+}
+
+func (d *UserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "User data source. Reads any user, including the users that are not managed by Terraform such as SSO accounts.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "User identifier. Exactly one of `id` or `username` must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username. Exactly one of `id` or `username` must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"first_name": schema.StringAttribute{
+				MarkdownDescription: "User first name.",
+				Computed:            true,
+			},
+			"last_name": schema.StringAttribute{
+				MarkdownDescription: "User last name.",
+				Computed:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "User email.",
+				Computed:            true,
+			},
+			"is_staff": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user is a staff user.",
+				Computed:            true,
+			},
+			"is_superuser": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user is a super user.",
+				Computed:            true,
+			},
+			"permission_groups": schema.SetAttribute{
+				MarkdownDescription: "The permission groups the user belongs to.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *UserDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("username"),
+		),
+	}
+}
+
+func (d *UserDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hwmux.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *hwmux.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UserDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// hwmux looks users up by username or ID
+	usernameOrId := data.Username.ValueString()
+	if data.Username.IsNull() {
+		usernameOrId = strconv.FormatInt(data.ID.ValueInt64(), 10)
+	}
+
+	user, _, err := GetUser(ctx, d.client, &resp.Diagnostics, usernameOrId)
+	if err != nil {
+		return
+	}
+
+	updateUserDataSourceModelFromResponse(user, &data)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Map response body to the data source model
+func updateUserDataSourceModelFromResponse(user *hwmux.LoggedInUser, data *UserDataSourceModel) {
+	data.ID = types.Int64Value(int64(user.GetId()))
+	data.Username = types.StringValue(user.GetUsername())
+	data.FirstName = types.StringValue(user.GetFirstName())
+	data.LastName = types.StringValue(user.GetLastName())
+	data.Email = types.StringValue(user.GetEmail())
+	data.IsStaff = types.BoolValue(user.GetIsStaff())
+	data.IsSuperuser = types.BoolValue(user.GetIsSuperuser())
+
+	data.PermissionGroups = make([]types.String, len(user.GetGroups()))
+	for i, aGroup := range user.GetGroups() {
+		data.PermissionGroups[i] = types.StringValue(aGroup)
+	}
+}
@@ -0,0 +1,33 @@
+package hwmux
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccUserDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read by username testing
+			{
+				Config: providerConfig + `data "hwmux_user" "test" {username = "dev1"}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hwmux_user.test", "username", "dev1"),
+					resource.TestCheckResourceAttrSet("data.hwmux_user.test", "id"),
+					resource.TestCheckResourceAttrSet("data.hwmux_user.test", "is_staff"),
+					resource.TestCheckResourceAttrSet("data.hwmux_user.test", "permission_groups.#"),
+				),
+			},
+			// Read by ID testing
+			{
+				Config: providerConfig + `data "hwmux_user" "test" {id = 1}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hwmux_user.test", "id", "1"),
+					resource.TestCheckResourceAttrSet("data.hwmux_user.test", "username"),
+				),
+			},
+		},
+	})
+}
@@ -7,12 +7,15 @@ import (
 	"time"
 
 	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -31,16 +34,20 @@ type UserResource struct {
 
 // UserResourceModel describes the resource data model.
 type UserResourceModel struct {
-	ID               types.String   `tfsdk:"id"`
-	Username         types.String   `tfsdk:"username"`
-	FirstName        types.String   `tfsdk:"first_name"`
-	LastName         types.String   `tfsdk:"last_name"`
-	Email            types.String   `tfsdk:"email"`
-	Password         types.String   `tfsdk:"password"`
-	IsStaff          types.Bool     `tfsdk:"is_staff"`
-	IsSuperuser      types.Bool     `tfsdk:"is_superuser"`
-	PermissionGroups []types.String `tfsdk:"permission_groups"`
-	LastUpdated      types.String   `tfsdk:"last_updated"`
+	ID                types.String   `tfsdk:"id"`
+	Username          types.String   `tfsdk:"username"`
+	FirstName         types.String   `tfsdk:"first_name"`
+	LastName          types.String   `tfsdk:"last_name"`
+	Email             types.String   `tfsdk:"email"`
+	Password          types.String   `tfsdk:"password"`
+	PasswordVersion   types.String   `tfsdk:"password_version"`
+	PasswordWO        types.String   `tfsdk:"password_wo"`
+	PasswordWOVersion types.String   `tfsdk:"password_wo_version"`
+	IsStaff           types.Bool     `tfsdk:"is_staff"`
+	IsSuperuser       types.Bool     `tfsdk:"is_superuser"`
+	PermissionGroups  []types.String `tfsdk:"permission_groups"`
+	LastUpdated       types.String   `tfsdk:"last_updated"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -50,7 +57,12 @@ func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataReques
 func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "User resource",
+		MarkdownDescription: "User resource. Exactly one of `password_wo` or `password` must be set to create a user, " +
+			"`password_wo` is recommended since it is never stored in the Terraform state. " +
+			"Existing users, such as SSO accounts, can be imported by username and managed without setting either, " +
+			"in which case their password is left untouched, as it is when both are removed from the configuration. " +
+			"hwmux never returns passwords, so changes made outside of Terraform are not detected: " +
+			"change `password_wo_version` or `password_version` to set the password again.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -62,16 +74,40 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			},
 			"username": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "Username.",
+				MarkdownDescription: "Username, which identifies the user in hwmux.",
+			},
+			"password_wo": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				MarkdownDescription: "Write-only user password, which is never stored in the Terraform state or plan. " +
+					"It is only sent to hwmux on creation and when `password_wo_version` changes. " +
+					"It can be generated with an ephemeral `random_password` resource. Requires Terraform 1.11 or later.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("password")),
+				},
+			},
+			"password_wo_version": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Arbitrary value that sets `password_wo` again when it changes.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("password_wo")),
+				},
 			},
 			"password": schema.StringAttribute{
-				Required:            true,
-				Sensitive:           true,
-				MarkdownDescription: "User password.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+				Optional:  true,
+				Sensitive: true,
+				MarkdownDescription: "User password. It is stored in cleartext in the Terraform state, " +
+					"use `password_wo` instead with Terraform 1.11 or later.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
 				},
 			},
+			"password_version": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Arbitrary value that sets `password` again when it changes.",
+			},
 			"first_name": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
@@ -106,6 +142,9 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Computed:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -139,6 +178,20 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "create", defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	var passwordWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	password, err := passwordFromPlan(data, passwordWO, &resp.Diagnostics)
+	if err != nil {
+		return
+	}
+
 	userSerializer, err := createUserFromPlan(data, &resp.Diagnostics)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -146,20 +199,21 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		)
 		return
 	}
+	userSerializer.SetPassword(password)
 
 	// create new user
-	userSerializer, httpRes, err := r.client.UserApi.UserCreate(context.Background()).LoggedInUser(*userSerializer).Execute()
+	userSerializer, httpRes, err := r.client.UserApi.UserCreate(ctx).LoggedInUser(*userSerializer).Execute()
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating user",
-			"Could not create user, unexpected error: "+err.Error()+"\n"+BodyToString(&httpRes.Body),
+			"Could not create user, unexpected error: "+err.Error()+"\n"+ResponseBodyToString(httpRes),
 		)
 		return
 	}
 
 	// process group membership
-	err = processUserPermissions(userSerializer, data, &resp.Diagnostics, r.client)
+	err = processUserPermissions(ctx, userSerializer, data, &resp.Diagnostics, r.client)
 	if err != nil {
 		return
 	}
@@ -188,8 +242,15 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "read", defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	// Get refreshed user value from hwmux
-	user, _, err := GetUser(r.client, &resp.Diagnostics, data.ID.ValueString())
+	var readDiagnostics diag.Diagnostics
+	user, httpRes, err := GetUser(ctx, r.client, &readDiagnostics, data.Username.ValueString())
+	if removeResourceIfNotFound(ctx, httpRes, readDiagnostics, resp, "User") {
+		return
+	}
 	if err != nil {
 		return
 	}
@@ -208,35 +269,51 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data *UserResourceModel
+	var state *UserResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	userSerializer, err := createUserFromPlan(data, &resp.Diagnostics)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to create user API request based on plan", err.Error(),
-		)
+	// the write-only password is only in the configuration
+	var passwordWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// update user
-	userSerializer, httpRes, err := r.client.UserApi.UserUpdate(context.Background(), data.ID.ValueString()).LoggedInUser(*userSerializer).Execute()
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "update", defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	// the password is only sent when it changes, with its own request
+	if passwordChanged(data, state, passwordWO) {
+		password, err := passwordFromPlan(data, passwordWO, &resp.Diagnostics)
+		if err != nil {
+			return
+		}
+		err = r.setPassword(ctx, &resp.Diagnostics, state.Username.ValueString(), password)
+		if err != nil {
+			return
+		}
+	}
+
+	// update user, using the current username since the plan may rename it
+	userSerializer, httpRes, err := r.client.UserApi.UserPartialUpdate(ctx, state.Username.ValueString()).PatchedLoggedInUser(*patchUserFromPlan(data)).Execute()
 
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error updating user "+data.ID.String(),
-			"Could not update user, unexpected error: "+err.Error()+"\n"+BodyToString(&httpRes.Body),
+			"Error updating user "+state.Username.String(),
+			"Could not update user, unexpected error: "+err.Error()+"\n"+ResponseBodyToString(httpRes),
 		)
 		return
 	}
 
 	// process group membership
-	err = processUserPermissions(userSerializer, data, &resp.Diagnostics, r.client)
+	err = processUserPermissions(ctx, userSerializer, data, &resp.Diagnostics, r.client)
 	if err != nil {
 		return
 	}
@@ -264,19 +341,40 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	ctx, cancel := contextWithTimeout(ctx, data.Timeouts, "delete", defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	// Delete existing
-	httpRes, err := r.client.UserApi.UserDestroy(context.Background(), data.ID.ValueString()).Execute()
+	httpRes, err := r.client.UserApi.UserDestroy(ctx, data.Username.ValueString()).Execute()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting User",
-			"Could not delete user, unexpected error: "+BodyToString(&httpRes.Body),
+			"Could not delete user, unexpected error: "+ResponseBodyToString(httpRes),
 		)
 		return
 	}
 }
 
 func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// hwmux looks users up by username or ID, Read replaces an ID by the username
+	resource.ImportStatePassthroughID(ctx, path.Root("username"), req, resp)
+}
+
+// Set the password of a user, without sending the other fields of the user.
+// hwmux has no endpoint setting a password, it is sent in a partial update of the user.
+func (r *UserResource) setPassword(ctx context.Context, diagnostics *diag.Diagnostics, id string, password string) error {
+	passwordRequest := hwmux.NewPatchedLoggedInUser()
+	passwordRequest.SetPassword(password)
+
+	_, httpRes, err := r.client.UserApi.UserPartialUpdate(ctx, id).PatchedLoggedInUser(*passwordRequest).Execute()
+	if err != nil {
+		diagnostics.AddError(
+			"Error setting password of user "+id,
+			"Could not set password, unexpected error: "+err.Error()+"\n"+ResponseBodyToString(httpRes),
+		)
+		return err
+	}
+	return nil
 }
 
 // Create a User based on a terraform plan
@@ -286,11 +384,51 @@ func createUserFromPlan(plan *UserResourceModel, diagnostics *diag.Diagnostics)
 	userSerializer.SetFirstName(plan.FirstName.ValueString())
 	userSerializer.SetLastName(plan.LastName.ValueString())
 	userSerializer.SetEmail(plan.Email.ValueString())
-	userSerializer.SetPassword(plan.Password.ValueString())
 
 	return userSerializer, nil
 }
 
+// Create a PatchedLoggedInUser with the fields of a terraform plan, except the password
+func patchUserFromPlan(plan *UserResourceModel) *hwmux.PatchedLoggedInUser {
+	userSerializer := hwmux.NewPatchedLoggedInUser()
+	userSerializer.SetUsername(plan.Username.ValueString())
+	userSerializer.SetFirstName(plan.FirstName.ValueString())
+	userSerializer.SetLastName(plan.LastName.ValueString())
+	userSerializer.SetEmail(plan.Email.ValueString())
+
+	return userSerializer
+}
+
+// Returns true if the password of the user must be set again, passwordWO being the configured write-only password
+func passwordChanged(plan *UserResourceModel, state *UserResourceModel, passwordWO types.String) bool {
+	// the write-only password is not in the state, only a version change or a switch from password sets it again
+	if !passwordWO.IsNull() {
+		return !plan.PasswordWOVersion.Equal(state.PasswordWOVersion) || !state.Password.IsNull()
+	}
+
+	// the password of users managed without password nor password_wo is left untouched
+	if plan.Password.IsNull() {
+		return false
+	}
+
+	return !plan.Password.Equal(state.Password) || !plan.PasswordVersion.Equal(state.PasswordVersion)
+}
+
+// Get the password to set from the plan or the configured write-only password
+func passwordFromPlan(plan *UserResourceModel, passwordWO types.String, diagnostics *diag.Diagnostics) (string, error) {
+	if passwordWO.IsNull() == plan.Password.IsNull() {
+		err := fmt.Errorf("exactly one of password_wo or password must be set for user %s, "+
+			"import the user to manage an existing user without setting its password", plan.Username.ValueString())
+		diagnostics.AddError("Invalid password configuration", err.Error())
+		return "", err
+	}
+
+	if !passwordWO.IsNull() {
+		return passwordWO.ValueString(), nil
+	}
+	return plan.Password.ValueString(), nil
+}
+
 // Map response body to model and populate Computed attribute values
 func updateUserModelFromResponse(user *hwmux.LoggedInUser, plan *UserResourceModel, diagnostics *diag.Diagnostics, client *hwmux.APIClient) (err error) {
 	// Map response body to schema and populate Computed attribute values
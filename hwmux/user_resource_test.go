@@ -1,8 +1,17 @@
 package hwmux
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -36,6 +45,14 @@ resource "hwmux_user" "test" {
 				// API, therefore there is no value for it during import.
 				ImportStateVerifyIgnore: []string{"last_updated", "password"},
 			},
+			// ImportState by username testing
+			{
+				ResourceName:            "hwmux_user.test",
+				ImportState:             true,
+				ImportStateId:           "team1_jenkins",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "password"},
+			},
 			// Update and Read testing
 			{
 				Config: providerConfig + `
@@ -51,7 +68,184 @@ resource "hwmux_user" "test" {
 					resource.TestCheckResourceAttr("hwmux_user.test", "permission_groups.0", "Staff users"),
 				),
 			},
+			// Write-only password testing
+			{
+				Config: providerConfig + `
+resource "hwmux_user" "test" {
+    username            = "team1_jenkins-2"
+    password_wo         = "a_password-3"
+    password_wo_version = "1"
+	permission_groups = ["Staff users"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hwmux_user.test", "username", "team1_jenkins-2"),
+					resource.TestCheckNoResourceAttr("hwmux_user.test", "password"),
+					resource.TestCheckNoResourceAttr("hwmux_user.test", "password_wo"),
+					resource.TestCheckResourceAttr("hwmux_user.test", "password_wo_version", "1"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestPasswordFromPlan(t *testing.T) {
+	var diagnostics diag.Diagnostics
+	plan := &UserResourceModel{Password: types.StringValue("a_password")}
+	password, err := passwordFromPlan(plan, types.StringNull(), &diagnostics)
+	if err != nil || password != "a_password" {
+		t.Fatalf("expected the configured password, got %q, %v", password, err)
+	}
+
+	plan = &UserResourceModel{Password: types.StringNull()}
+	password, err = passwordFromPlan(plan, types.StringValue("a_write_only_password"), &diagnostics)
+	if err != nil || password != "a_write_only_password" {
+		t.Fatalf("expected the write-only password, got %q, %v", password, err)
+	}
+
+	for _, passwords := range [][2]types.String{
+		{types.StringNull(), types.StringNull()},
+		{types.StringValue("a_password"), types.StringValue("a_write_only_password")},
+	} {
+		var diagnostics diag.Diagnostics
+		plan := &UserResourceModel{Password: passwords[0]}
+		if _, err := passwordFromPlan(plan, passwords[1], &diagnostics); err == nil || !diagnostics.HasError() {
+			t.Errorf("expected an error for password %s and password_wo %s", passwords[0], passwords[1])
+		}
+	}
+}
+
+func TestPasswordChanged(t *testing.T) {
+	state := &UserResourceModel{
+		Password:          types.StringValue("a_password"),
+		PasswordVersion:   types.StringValue("1"),
+		PasswordWOVersion: types.StringNull(),
+	}
+	writeOnlyState := &UserResourceModel{
+		Password:          types.StringNull(),
+		PasswordVersion:   types.StringNull(),
+		PasswordWOVersion: types.StringValue("1"),
+	}
+
+	cases := map[string]struct {
+		plan       UserResourceModel
+		state      *UserResourceModel
+		passwordWO types.String
+		expected   bool
+	}{
+		"unchanged":        {UserResourceModel{Password: state.Password, PasswordVersion: state.PasswordVersion}, state, types.StringNull(), false},
+		"password":         {UserResourceModel{Password: types.StringValue("b"), PasswordVersion: state.PasswordVersion}, state, types.StringNull(), true},
+		"password_version": {UserResourceModel{Password: state.Password, PasswordVersion: types.StringValue("2")}, state, types.StringNull(), true},
+		"password removed": {UserResourceModel{Password: types.StringNull(), PasswordVersion: state.PasswordVersion}, state, types.StringNull(), false},
+		"unmanaged password_version": {UserResourceModel{Password: types.StringNull(), PasswordVersion: types.StringValue("2")},
+			state, types.StringNull(), false},
+		"switched to password_wo": {UserResourceModel{Password: types.StringNull(), PasswordWOVersion: types.StringNull()},
+			state, types.StringValue("b"), true},
+		"password_wo unchanged version": {UserResourceModel{Password: types.StringNull(), PasswordWOVersion: types.StringValue("1")},
+			writeOnlyState, types.StringValue("b"), false},
+		"password_wo_version": {UserResourceModel{Password: types.StringNull(), PasswordWOVersion: types.StringValue("2")},
+			writeOnlyState, types.StringValue("b"), true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if changed := passwordChanged(&tc.plan, tc.state, tc.passwordWO); changed != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, changed)
+			}
+		})
+	}
+}
+
+func TestUserCreateSendsWriteOnlyPassword(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(req.Body).Decode(&body)
+		if body["password"] != "a_write_only_password" {
+			t.Errorf("expected the write-only password to be sent, got %v", body["password"])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": 7, "username": "alice", "groups": ["All users"], "password": ""}`))
+	})
+
+	r := NewUserResource()
+	r.(tfresource.ResourceWithConfigure).Configure(ctx, tfresource.ConfigureRequest{ProviderData: client}, &tfresource.ConfigureResponse{})
+
+	schemaResp := &tfresource.SchemaResponse{}
+	r.Schema(ctx, tfresource.SchemaRequest{}, schemaResp)
+	timeoutsType := schemaResp.Schema.Blocks["timeouts"].Type().(timeouts.Type)
+	data := &UserResourceModel{
+		ID:                types.StringUnknown(),
+		Username:          types.StringValue("alice"),
+		FirstName:         types.StringUnknown(),
+		LastName:          types.StringUnknown(),
+		Email:             types.StringUnknown(),
+		Password:          types.StringNull(),
+		PasswordVersion:   types.StringNull(),
+		PasswordWO:        types.StringValue("a_write_only_password"),
+		PasswordWOVersion: types.StringNull(),
+		IsStaff:           types.BoolUnknown(),
+		IsSuperuser:       types.BoolUnknown(),
+		PermissionGroups:  []types.String{types.StringValue("All users")},
+		LastUpdated:       types.StringUnknown(),
+		Timeouts:          timeouts.Value{Object: types.ObjectNull(timeoutsType.AttrTypes)},
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	diags := plan.Set(ctx, data)
+	config := tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}
+	// write-only attributes are null in the plan
+	data.PasswordWO = types.StringNull()
+	diags.Append(plan.Set(ctx, data)...)
+	if diags.HasError() {
+		t.Fatalf("unable to build the plan: %v", diags)
+	}
+
+	resp := &tfresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Create(ctx, tfresource.CreateRequest{Config: config, Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create error: %v", resp.Diagnostics)
+	}
+
+	var state UserResourceModel
+	resp.State.Get(ctx, &state)
+	if !state.PasswordWO.IsNull() || !state.Password.IsNull() {
+		t.Errorf("expected no password in the state, got %s and %s", state.PasswordWO, state.Password)
+	}
+}
+
+func TestUserImportByUsername(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/user/alice/" {
+			t.Errorf("expected the user to be looked up by username, got %s", req.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 7, "username": "alice", "groups": ["All users"], "password": ""}`))
+	})
+
+	r := NewUserResource()
+	r.(tfresource.ResourceWithConfigure).Configure(ctx, tfresource.ConfigureRequest{ProviderData: client}, &tfresource.ConfigureResponse{})
+
+	importResp := &tfresource.ImportStateResponse{State: newFakeResourceState(t, r, map[string]string{})}
+	r.(tfresource.ResourceWithImportState).ImportState(ctx, tfresource.ImportStateRequest{ID: "alice"}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected import error: %v", importResp.Diagnostics)
+	}
+
+	readResp := &tfresource.ReadResponse{State: importResp.State}
+	r.Read(ctx, tfresource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read error: %v", readResp.Diagnostics)
+	}
+
+	var data UserResourceModel
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &data)...)
+	if data.ID.ValueString() != "7" || data.Username.ValueString() != "alice" {
+		t.Errorf("expected user 7 alice, got %s %s", data.ID, data.Username)
+	}
+	if !data.Password.IsNull() {
+		t.Errorf("expected the password of the adopted user to be unset, got %s", data.Password)
+	}
+}
@@ -0,0 +1,166 @@
+package hwmux
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &UsersDataSource{}
+
+func NewUsersDataSource() datasource.DataSource {
+	return &UsersDataSource{}
+}
+
+type UsersDataSource struct {
+	client *hwmux.APIClient
+}
+
+// usersDataSourceModel maps the data source schema data.
+type UsersDataSourceModel struct {
+	ID              types.String          `tfsdk:"id"`
+	PermissionGroup types.String          `tfsdk:"permission_group"`
+	IsStaff         types.Bool            `tfsdk:"is_staff"`
+	UsernamePrefix  types.String          `tfsdk:"username_prefix"`
+	Users           []UserDataSourceModel `tfsdk:"users"`
+}
+
+func (d *UsersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (d *UsersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Users data source. Lists all the users matching the given filters.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder identifier. Set to satisfy terraform restrictions.",
+				Computed:            true,
+			},
+			"permission_group": schema.StringAttribute{
+				MarkdownDescription: "Only return the users that belong to this permission group.",
+				Optional:            true,
+			},
+			"is_staff": schema.BoolAttribute{
+				MarkdownDescription: "Only return users that are (true) or are not (false) staff users.",
+				Optional:            true,
+			},
+			"username_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only return the users whose username starts with this prefix.",
+				Optional:            true,
+			},
+			"users": schema.ListNestedAttribute{
+				MarkdownDescription: "The users matching the filters.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "User identifier.",
+							Computed:            true,
+						},
+						"username": schema.StringAttribute{
+							MarkdownDescription: "Username.",
+							Computed:            true,
+						},
+						"first_name": schema.StringAttribute{
+							MarkdownDescription: "User first name.",
+							Computed:            true,
+						},
+						"last_name": schema.StringAttribute{
+							MarkdownDescription: "User last name.",
+							Computed:            true,
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "User email.",
+							Computed:            true,
+						},
+						"is_staff": schema.BoolAttribute{
+							MarkdownDescription: "Whether the user is a staff user.",
+							Computed:            true,
+						},
+						"is_superuser": schema.BoolAttribute{
+							MarkdownDescription: "Whether the user is a super user.",
+							Computed:            true,
+						},
+						"permission_groups": schema.SetAttribute{
+							MarkdownDescription: "The permission groups the user belongs to.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *UsersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hwmux.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *hwmux.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UsersDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the filters supported by the API are applied server side
+	request := d.client.UserApi.UserList(ctx)
+	if !data.PermissionGroup.IsNull() {
+		request = request.UserGroup(data.PermissionGroup.ValueString())
+	}
+
+	users, err := ListUsers(&resp.Diagnostics, request)
+	if err != nil {
+		return
+	}
+
+	// the remaining filters are applied client side
+	data.Users = []UserDataSourceModel{}
+	for i := range users {
+		user := &users[i]
+		if !data.IsStaff.IsNull() && user.GetIsStaff() != data.IsStaff.ValueBool() {
+			continue
+		}
+		if !strings.HasPrefix(user.GetUsername(), data.UsernamePrefix.ValueString()) {
+			continue
+		}
+
+		var userModel UserDataSourceModel
+		updateUserDataSourceModelFromResponse(user, &userModel)
+		data.Users = append(data.Users, userModel)
+	}
+
+	data.ID = types.StringValue("users")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
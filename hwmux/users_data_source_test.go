@@ -0,0 +1,72 @@
+package hwmux
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccUsersDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "hwmux_users" "test" {
+	permission_group = "All users"
+	username_prefix  = "dev"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.hwmux_users.test", "users.#"),
+					resource.TestCheckResourceAttrSet("data.hwmux_users.test", "users.0.id"),
+					resource.TestCheckTypeSetElemAttr("data.hwmux_users.test", "users.0.permission_groups.*", "All users"),
+				),
+			},
+		},
+	})
+}
+
+func TestUsersDataSourceFilters(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeHwmuxClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("user_group") != "Team" {
+			t.Errorf("expected the permission group filter to be sent to hwmux, got %q", req.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"count": 3, "next": null, "results": [
+			{"id": 1, "username": "ci_build", "is_staff": false, "groups": ["Team"], "password": ""},
+			{"id": 2, "username": "ci_admin", "is_staff": true, "groups": ["Team"], "password": ""},
+			{"id": 3, "username": "alice", "is_staff": false, "groups": ["Team"], "password": ""}
+		]}`))
+	})
+
+	d := NewUsersDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	config := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	config.SetAttribute(ctx, path.Root("permission_group"), "Team")
+	config.SetAttribute(ctx, path.Root("is_staff"), false)
+	config.SetAttribute(ctx, path.Root("username_prefix"), "ci_")
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var data UsersDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if len(data.Users) != 1 || data.Users[0].Username.ValueString() != "ci_build" {
+		t.Fatalf("expected only ci_build to match the filters, got %v", data.Users)
+	}
+}
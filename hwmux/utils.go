@@ -4,8 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
+	"reflect"
+	"regexp"
 
+	"github.com/Silabs-UTF/hwmux-client-golang/v2"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -19,6 +24,14 @@ func BodyToString(body *io.ReadCloser) string {
 	return bodyStr
 }
 
+// Get string payload from an http.Response, which is nil when the request was not sent
+func ResponseBodyToString(httpRes *http.Response) string {
+	if httpRes == nil {
+		return ""
+	}
+	return BodyToString(&httpRes.Body)
+}
+
 // Unmarshal metadata, set Diagnostics, return metadata and error
 func UnmarshalMetadataSetError(data string, diagnostics *diag.Diagnostics, resourceName string) (*map[string]interface{}, error) {
 	var metadata map[string]interface{}
@@ -33,7 +46,9 @@ func UnmarshalMetadataSetError(data string, diagnostics *diag.Diagnostics, resou
 	return &metadata, nil
 }
 
-// Unmarshal metadata, set Diagnostics, set field and return error
+// Marshal metadata, set Diagnostics, set field and return error.
+// The field is left untouched if it already holds json that is semantically equal to the metadata,
+// so that the formatting and key order of the configuration are preserved.
 func MarshalMetadataSetError(metadata map[string]interface{}, diagnostics *diag.Diagnostics, resourceName string, field *types.String) error {
 	metadataJson, err := json.Marshal(metadata)
 	if err != nil {
@@ -43,6 +58,130 @@ func MarshalMetadataSetError(metadata map[string]interface{}, diagnostics *diag.
 		)
 		return err
 	}
+	if !field.IsNull() && !field.IsUnknown() && JSONEqual(field.ValueString(), string(metadataJson)) {
+		return nil
+	}
 	*field = types.StringValue(string(metadataJson))
 	return nil
 }
+
+// Returns true if both strings are valid json encoding the same value, ignoring whitespace and key order
+func JSONEqual(a string, b string) bool {
+	var aValue, bValue interface{}
+	if err := json.Unmarshal([]byte(a), &aValue); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(b), &bValue); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(aValue, bValue)
+}
+
+// Returns true if the metadata contains all the key/value pairs of the filter.
+// Values that are not strings are compared using their json encoding.
+func MetadataMatches(metadata map[string]interface{}, filter map[string]string) bool {
+	for key, expected := range filter {
+		value, ok := metadata[key]
+		if !ok {
+			return false
+		}
+		if valueStr, isString := value.(string); isString {
+			if valueStr != expected {
+				return false
+			}
+			continue
+		}
+		valueJson, err := json.Marshal(value)
+		if err != nil || string(valueJson) != expected {
+			return false
+		}
+	}
+	return true
+}
+
+// Convert the metadata filter of a plural data source to the format expected by MetadataMatches
+func metadataFilterFromConfig(filter map[string]types.String) map[string]string {
+	metadataFilter := make(map[string]string, len(filter))
+	for key, value := range filter {
+		metadataFilter[key] = value.ValueString()
+	}
+	return metadataFilter
+}
+
+// Compile the regular expression of a plural data source filter. Returns nil when the filter is not set.
+func regexpFilterFromConfig(filter types.String, attribute string, diagnostics *diag.Diagnostics) (*regexp.Regexp, error) {
+	if filter.IsNull() {
+		return nil, nil
+	}
+	filterRegexp, err := regexp.Compile(filter.ValueString())
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root(attribute),
+			"Invalid regular expression",
+			err.Error(),
+		)
+	}
+	return filterRegexp, err
+}
+
+// Returns true if the values contain the given value
+func containsString(values []string, value string) bool {
+	for _, aValue := range values {
+		if aValue == value {
+			return true
+		}
+	}
+	return false
+}
+
+// All the values of hwmux.SourceEnum as strings
+func sourceEnumValues() []string {
+	values := make([]string, len(hwmux.AllowedSourceEnumEnumValues))
+	for i, value := range hwmux.AllowedSourceEnumEnumValues {
+		values[i] = string(value)
+	}
+	return values
+}
+
+// All the values of hwmux.StatusEnum as strings
+func statusEnumValues() []string {
+	values := make([]string, len(hwmux.AllowedStatusEnumEnumValues))
+	for i, value := range hwmux.AllowedStatusEnumEnumValues {
+		values[i] = string(value)
+	}
+	return values
+}
+
+// All the values of hwmux.PermissionsEnum as strings
+func permissionsEnumValues() []string {
+	values := make([]string, len(hwmux.AllowedPermissionsEnumEnumValues))
+	for i, value := range hwmux.AllowedPermissionsEnumEnumValues {
+		values[i] = string(value)
+	}
+	return values
+}
+
+// The comment recorded in hwmux with a status change, defaulting to a generic one when none is configured
+func statusCommentFromPlan(comment types.String) string {
+	if comment.IsNull() || comment.IsUnknown() {
+		return "Status set via Terraform"
+	}
+	return comment.ValueString()
+}
+
+// Convert a nullable hwmux string to a terraform string, keeping null values
+func NullableStringValue(value hwmux.NullableString) types.String {
+	if value.Get() == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(*value.Get())
+}
+
+// Convert a terraform string to a nullable hwmux string. Null and unknown values are sent as null.
+func NullableStringFromValue(value types.String) hwmux.NullableString {
+	if value.IsNull() || value.IsUnknown() {
+		return *hwmux.NewNullableString(nil)
+	}
+	str := value.ValueString()
+	return *hwmux.NewNullableString(&str)
+}
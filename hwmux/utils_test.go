@@ -0,0 +1,85 @@
+package hwmux
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMetadataMatches(t *testing.T) {
+	metadata := map[string]interface{}{
+		"board":    "BRD4180",
+		"channels": float64(4),
+		"enabled":  true,
+	}
+
+	testCases := map[string]struct {
+		filter   map[string]string
+		expected bool
+	}{
+		"empty filter":         {map[string]string{}, true},
+		"string value":         {map[string]string{"board": "BRD4180"}, true},
+		"non string values":    {map[string]string{"channels": "4", "enabled": "true"}, true},
+		"wrong value":          {map[string]string{"board": "BRD4181"}, false},
+		"missing key":          {map[string]string{"missing": "value"}, false},
+		"one of many mismatch": {map[string]string{"board": "BRD4180", "enabled": "false"}, false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := MetadataMatches(metadata, tc.filter); got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestJSONEqual(t *testing.T) {
+	testCases := map[string]struct {
+		a, b     string
+		expected bool
+	}{
+		"identical":       {`{"a":1}`, `{"a":1}`, true},
+		"whitespace":      {`{ "a" : 1 }`, `{"a":1}`, true},
+		"key order":       {`{"b":{"d":2,"c":1},"a":[1,2]}`, `{"a":[1,2],"b":{"c":1,"d":2}}`, true},
+		"different value": {`{"a":1}`, `{"a":2}`, false},
+		"list order":      {`{"a":[1,2]}`, `{"a":[2,1]}`, false},
+		"invalid json":    {`{"a":`, `{"a":1}`, false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := JSONEqual(tc.a, tc.b); got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestMarshalMetadataSetErrorKeepsEquivalentJSON(t *testing.T) {
+	metadata := map[string]interface{}{"b": float64(1), "a": "x"}
+
+	testCases := map[string]struct {
+		field    types.String
+		expected string
+	}{
+		"null":       {types.StringNull(), `{"a":"x","b":1}`},
+		"unknown":    {types.StringUnknown(), `{"a":"x","b":1}`},
+		"equivalent": {types.StringValue("{\n  \"b\": 1,\n  \"a\": \"x\"\n}"), "{\n  \"b\": 1,\n  \"a\": \"x\"\n}"},
+		"changed":    {types.StringValue(`{"b": 2, "a": "x"}`), `{"a":"x","b":1}`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diagnostics diag.Diagnostics
+			field := tc.field
+			if err := MarshalMetadataSetError(metadata, &diagnostics, "test", &field); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if field.ValueString() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, field.ValueString())
+			}
+		})
+	}
+}